@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"log"
 	"talana_prueba_tecnica/src/app/module"
 	"talana_prueba_tecnica/src/shared"
@@ -16,19 +18,39 @@ import (
 // @description API para la gestión de trivias, destinada a ser prueba tecnica de juan martinez simi, para talana
 // @host localhost:8080
 // @BasePath /
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description Access token as "Bearer <token>"
 
 func main() {
+	grantAdmin := flag.String("grant-admin", "", "grant the admin role to the registered user with this email and exit")
+	flag.Parse()
+
+	if *grantAdmin != "" {
+		if err := module.GrantAdmin(context.Background(), *grantAdmin); err != nil {
+			log.Fatalf("Error granting admin role: %v", err)
+		}
+		log.Printf("Admin role granted to %s", *grantAdmin)
+		return
+	}
+
 	log.Println("Starting server...")
 	e := fiber.New()
 	envs := shared.GetEnvs()
+	shared.CheckEnvs(envs)
 	shared.Init()
 
 	e.Get("/swagger/*", fiberSwagger.WrapHandler)
 
+	module.AuthModule(e)
 	module.UserModule(e)
 	module.QuestionModule(e)
+	module.CategoryModule(e)
 	module.TriviaModule(e)
 	module.GameModule(e)
+	module.RoomModule(e)
+	module.LeaderboardModule(e)
 	err := e.Listen(":" + envs["PORT"])
 	if err != nil {
 		log.Fatalf("Error starting server: %v", err)
@@ -0,0 +1,23 @@
+package module
+
+import (
+	authusecase "talana_prueba_tecnica/src/app/usecases/auth_usecase"
+	"talana_prueba_tecnica/src/infraestructure/handlers"
+	"talana_prueba_tecnica/src/infraestructure/middleware"
+	repository "talana_prueba_tecnica/src/infraestructure/repository/user_repository"
+	"talana_prueba_tecnica/src/shared"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func AuthModule(app *fiber.App) {
+	db := shared.Init()
+	userRepo := repository.NewUserRepository(db)
+	authUseCase := authusecase.NewAuthUseCase(userRepo)
+	authHandler := handlers.NewAuthHandler(authUseCase)
+
+	app.Post("/auth/register", authHandler.Register)
+	app.Post("/auth/login", authHandler.Login)
+	app.Post("/auth/refresh", authHandler.Refresh)
+	app.Get("/auth/me", middleware.Authenticate(userRepo), authHandler.Me)
+}
@@ -0,0 +1,34 @@
+package module
+
+import (
+	categoryusecase "talana_prueba_tecnica/src/app/usecases/category_usecase"
+	tagusecase "talana_prueba_tecnica/src/app/usecases/tag_usecase"
+	"talana_prueba_tecnica/src/entity/models"
+	"talana_prueba_tecnica/src/infraestructure/handlers"
+	"talana_prueba_tecnica/src/infraestructure/middleware"
+	categoryrepository "talana_prueba_tecnica/src/infraestructure/repository/category_repository"
+	tagrepository "talana_prueba_tecnica/src/infraestructure/repository/tag_repository"
+	repository "talana_prueba_tecnica/src/infraestructure/repository/user_repository"
+	"talana_prueba_tecnica/src/shared"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func CategoryModule(app *fiber.App) {
+	db := shared.Init()
+	categoryHandler := handlers.NewCategoryHandler(categoryusecase.NewCategoryUseCase(categoryrepository.NewCategoryRepository(db)))
+	tagHandler := handlers.NewTagHandler(tagusecase.NewTagUseCase(tagrepository.NewTagRepository(db)))
+	userRepo := repository.NewUserRepository(db)
+	auth := middleware.Authenticate(userRepo)
+	authors := middleware.RequireRoles(models.RoleAuthor, models.RoleAdmin)
+
+	app.Get("/categories", auth, categoryHandler.GetAllCategories)
+	app.Get("/categories/:id", auth, categoryHandler.GetCategoryByID)
+	app.Post("/categories", auth, authors, categoryHandler.CreateCategory)
+	app.Put("/categories/:id", auth, authors, categoryHandler.UpdateCategory)
+	app.Delete("/categories/:id", auth, authors, categoryHandler.DeleteCategory)
+	app.Get("/tags", auth, tagHandler.GetAllTags)
+	app.Post("/tags", auth, authors, tagHandler.CreateTag)
+	app.Put("/tags/:id", auth, authors, tagHandler.UpdateTag)
+	app.Delete("/tags/:id", auth, authors, tagHandler.DeleteTag)
+}
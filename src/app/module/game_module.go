@@ -3,10 +3,14 @@ package module
 import (
 	"github.com/gofiber/fiber/v2"
 	"talana_prueba_tecnica/src/app/usecases/game_usecase"
+	"talana_prueba_tecnica/src/entity/models"
 	"talana_prueba_tecnica/src/infraestructure/handlers"
+	"talana_prueba_tecnica/src/infraestructure/middleware"
 	"talana_prueba_tecnica/src/infraestructure/repository/game_repository"
 	questionsrepository "talana_prueba_tecnica/src/infraestructure/repository/questions_repository"
+	sessionrepository "talana_prueba_tecnica/src/infraestructure/repository/session_repository"
 	triviarepository "talana_prueba_tecnica/src/infraestructure/repository/trivia_repository"
+	repository "talana_prueba_tecnica/src/infraestructure/repository/user_repository"
 	"talana_prueba_tecnica/src/shared"
 )
 
@@ -15,10 +19,17 @@ func GameModule(app *fiber.App) {
 	gameRepo := game_repository.NewGameRepository(db)
 	questionRepo := questionsrepository.NewQuestionRepository(db)
 	triviaReRepo := triviarepository.NewTriviaRepository(db)
-	gameUseCase := game_usecase.NewGameUseCase(gameRepo, questionRepo, triviaReRepo)
+	sessionRepo := sessionrepository.NewSessionRepository(db)
+	userRepo := repository.NewUserRepository(db)
+	gameUseCase := game_usecase.NewGameUseCase(gameRepo, questionRepo, triviaReRepo, sessionRepo, shared.NewUnitOfWork(db))
 	gamerHandler := handlers.NewGameHandler(gameUseCase)
+	auth := middleware.Authenticate(userRepo)
+	players := middleware.RequireRoles(models.RolePlayer)
+	authors := middleware.RequireRoles(models.RoleAuthor, models.RoleAdmin)
 
-	app.Get("/games/trivias/:id/questions", gamerHandler.GetQuestionsForTrivia)
-	app.Post("/games/trivias/:id/answers", gamerHandler.SubmitAnswers)
-
+	app.Get("/games/trivias/:id/questions", auth, authors, gamerHandler.GetQuestionsForTrivia)
+	app.Post("/games/trivias/:id/answers", auth, players, gamerHandler.SubmitAnswers)
+	app.Post("/games/trivias/:id/sessions", auth, players, gamerHandler.StartSession)
+	app.Get("/games/trivias/:id/sessions/:sid/next", auth, players, gamerHandler.NextQuestion)
+	app.Post("/games/trivias/:id/sessions/:sid/answer", auth, players, gamerHandler.AnswerQuestion)
 }
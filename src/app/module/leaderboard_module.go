@@ -0,0 +1,27 @@
+package module
+
+import (
+	leaderboardusecase "talana_prueba_tecnica/src/app/usecases/leaderboard_usecase"
+	"talana_prueba_tecnica/src/entity/models"
+	"talana_prueba_tecnica/src/infraestructure/handlers"
+	"talana_prueba_tecnica/src/infraestructure/middleware"
+	leaderboardrepository "talana_prueba_tecnica/src/infraestructure/repository/leaderboard_repository"
+	repository "talana_prueba_tecnica/src/infraestructure/repository/user_repository"
+	"talana_prueba_tecnica/src/shared"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func LeaderboardModule(app *fiber.App) {
+	db := shared.Init()
+	leaderboardRepo := leaderboardrepository.NewLeaderboardRepository(db)
+	leaderboardUseCase := leaderboardusecase.NewLeaderboardUseCase(leaderboardRepo)
+	leaderboardHandler := handlers.NewLeaderboardHandler(leaderboardUseCase)
+	userRepo := repository.NewUserRepository(db)
+	auth := middleware.Authenticate(userRepo)
+
+	app.Get("/leaderboards/global", auth, leaderboardHandler.GetGlobalLeaderboard)
+	app.Get("/leaderboards/seasons", auth, leaderboardHandler.GetAllSeasons)
+	app.Post("/leaderboards/seasons", auth, middleware.RequireRoles(models.RoleAdmin), leaderboardHandler.CreateSeason)
+	app.Get("/leaderboards/seasons/:season", auth, leaderboardHandler.GetSeasonLeaderboard)
+}
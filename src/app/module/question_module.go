@@ -2,8 +2,16 @@ package module
 
 import (
 	questionsusecase "talana_prueba_tecnica/src/app/usecases/questions_usecase"
+	reviewusecase "talana_prueba_tecnica/src/app/usecases/review_usecase"
+	"talana_prueba_tecnica/src/entity/models"
 	"talana_prueba_tecnica/src/infraestructure/handlers"
+	"talana_prueba_tecnica/src/infraestructure/middleware"
+	categoryrepository "talana_prueba_tecnica/src/infraestructure/repository/category_repository"
+	idempotencyrepository "talana_prueba_tecnica/src/infraestructure/repository/idempotency_repository"
 	questionsrepository "talana_prueba_tecnica/src/infraestructure/repository/questions_repository"
+	reviewrepository "talana_prueba_tecnica/src/infraestructure/repository/review_repository"
+	tagrepository "talana_prueba_tecnica/src/infraestructure/repository/tag_repository"
+	repository "talana_prueba_tecnica/src/infraestructure/repository/user_repository"
 	"talana_prueba_tecnica/src/shared"
 
 	"github.com/gofiber/fiber/v2"
@@ -12,13 +20,33 @@ import (
 func QuestionModule(app *fiber.App) {
 	db := shared.Init()
 	questionRepo := questionsrepository.NewQuestionRepository(db)
-	useCase := questionsusecase.NewQuestionsUseCase(questionRepo)
+	useCase := questionsusecase.NewQuestionsUseCase(
+		questionRepo,
+		categoryrepository.NewCategoryRepository(db),
+		tagrepository.NewTagRepository(db),
+		reviewusecase.NewReviewUseCase(reviewrepository.NewReviewRepository(db)),
+		shared.NewUnitOfWork(db),
+	)
 	handler := handlers.NewQuestionHandler(useCase)
+	userRepo := repository.NewUserRepository(db)
+	auth := middleware.Authenticate(userRepo)
+	authors := middleware.RequireRoles(models.RoleAuthor, models.RoleAdmin)
+	admins := middleware.RequireRoles(models.RoleAdmin)
+	idempotent := middleware.Idempotency(idempotencyrepository.NewIdempotencyRepository(db))
 
-	app.Get("/questions", handler.GetAllQuestions)
-	app.Get("/questions/:id", handler.GetQuestionByID)
-	app.Get("/questions ", handler.FullTextSearch)
-	app.Post("/questions", handler.CreateQuestion)
-	app.Put("/questions/:id", handler.UpdateQuestion)
-	app.Delete("/questions/:id", handler.DeleteQuestion)
+	app.Get("/questions", auth, handler.GetAllQuestions)
+	app.Get("/questions/export", auth, authors, handler.ExportQuestions)
+	app.Get("/questions/:id", auth, handler.GetQuestionByID)
+	app.Get("/questions/:id/revisions", auth, authors, handler.GetQuestionRevisions)
+	app.Get("/questions/:id/status/history", auth, authors, handler.GetQuestionStatusHistory)
+	app.Get("/questions ", auth, handler.FullTextSearch)
+	app.Post("/questions", auth, authors, idempotent, handler.CreateQuestion)
+	app.Post("/questions/import", auth, authors, idempotent, handler.ImportQuestions)
+	app.Post("/questions/:id/status", auth, authors, handler.ChangeQuestionStatus)
+	app.Put("/questions/:id", auth, authors, handler.UpdateQuestion)
+	app.Delete("/questions/:id", auth, authors, handler.DeleteQuestion)
+	app.Post("/questions/:id/restore", auth, admins, handler.RestoreQuestion)
+	app.Delete("/questions/:id/purge", auth, admins, handler.PurgeQuestion)
+	app.Get("/author/questions", auth, authors, handler.GetAllQuestionsForAuthor)
+	app.Get("/author/questions/:id", auth, authors, handler.GetQuestionByIDForAuthor)
 }
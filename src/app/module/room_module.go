@@ -0,0 +1,37 @@
+package module
+
+import (
+	"talana_prueba_tecnica/src/app/usecases/game_usecase"
+	roomusecase "talana_prueba_tecnica/src/app/usecases/room_usecase"
+	"talana_prueba_tecnica/src/entity/models"
+	"talana_prueba_tecnica/src/infraestructure/handlers"
+	"talana_prueba_tecnica/src/infraestructure/middleware"
+	"talana_prueba_tecnica/src/infraestructure/repository/game_repository"
+	questionsrepository "talana_prueba_tecnica/src/infraestructure/repository/questions_repository"
+	sessionrepository "talana_prueba_tecnica/src/infraestructure/repository/session_repository"
+	triviarepository "talana_prueba_tecnica/src/infraestructure/repository/trivia_repository"
+	repository "talana_prueba_tecnica/src/infraestructure/repository/user_repository"
+	"talana_prueba_tecnica/src/shared"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+)
+
+func RoomModule(app *fiber.App) {
+	db := shared.Init()
+	gameRepo := game_repository.NewGameRepository(db)
+	questionRepo := questionsrepository.NewQuestionRepository(db)
+	triviaRepo := triviarepository.NewTriviaRepository(db)
+	sessionRepo := sessionrepository.NewSessionRepository(db)
+	userRepo := repository.NewUserRepository(db)
+	gameUseCase := game_usecase.NewGameUseCase(gameRepo, questionRepo, triviaRepo, sessionRepo, shared.NewUnitOfWork(db))
+	roomUseCase := roomusecase.NewRoomUseCase(gameUseCase, triviaRepo)
+	roomHandler := handlers.NewRoomHandler(roomUseCase)
+	auth := middleware.Authenticate(userRepo)
+
+	authors := middleware.RequireRoles(models.RoleAuthor, models.RoleAdmin)
+
+	app.Post("/rooms", auth, authors, roomHandler.CreateRoom)
+	app.Get("/rooms/:code", auth, roomHandler.GetRoom)
+	app.Get("/rooms/:code/ws", roomHandler.Upgrade, auth, websocket.New(roomHandler.Connect))
+}
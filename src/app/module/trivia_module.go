@@ -1,9 +1,17 @@
 package module
 
 import (
+	questionsusecase "talana_prueba_tecnica/src/app/usecases/questions_usecase"
+	reviewusecase "talana_prueba_tecnica/src/app/usecases/review_usecase"
 	triviausecase "talana_prueba_tecnica/src/app/usecases/trivia_usecase"
+	"talana_prueba_tecnica/src/entity/models"
 	"talana_prueba_tecnica/src/infraestructure/handlers"
+	"talana_prueba_tecnica/src/infraestructure/middleware"
+	categoryrepository "talana_prueba_tecnica/src/infraestructure/repository/category_repository"
+	idempotencyrepository "talana_prueba_tecnica/src/infraestructure/repository/idempotency_repository"
 	questionsrepository "talana_prueba_tecnica/src/infraestructure/repository/questions_repository"
+	reviewrepository "talana_prueba_tecnica/src/infraestructure/repository/review_repository"
+	tagrepository "talana_prueba_tecnica/src/infraestructure/repository/tag_repository"
 	triviarepository "talana_prueba_tecnica/src/infraestructure/repository/trivia_repository"
 	repository "talana_prueba_tecnica/src/infraestructure/repository/user_repository"
 	"talana_prueba_tecnica/src/shared"
@@ -16,13 +24,39 @@ func TriviaModule(app *fiber.App) {
 	triviaRepo := triviarepository.NewTriviaRepository(db)
 	userRepo := repository.NewUserRepository(db)
 	questionRepo := questionsrepository.NewQuestionRepository(db)
-	triviaUseCase := triviausecase.NewTriviaUseCase(triviaRepo, userRepo, questionRepo)
+	unitOfWork := shared.NewUnitOfWork(db)
+	reviewUseCase := reviewusecase.NewReviewUseCase(reviewrepository.NewReviewRepository(db))
+	questionUseCase := questionsusecase.NewQuestionsUseCase(
+		questionRepo,
+		categoryrepository.NewCategoryRepository(db),
+		tagrepository.NewTagRepository(db),
+		reviewUseCase,
+		unitOfWork,
+	)
+	triviaUseCase := triviausecase.NewTriviaUseCase(triviaRepo, userRepo, questionRepo, questionUseCase, reviewUseCase, unitOfWork)
 	triviaHandler := handlers.NewTriviaHandler(triviaUseCase)
+	auth := middleware.Authenticate(userRepo)
+	authors := middleware.RequireRoles(models.RoleAuthor, models.RoleAdmin)
+	admins := middleware.RequireRoles(models.RoleAdmin)
+	idempotent := middleware.Idempotency(idempotencyrepository.NewIdempotencyRepository(db))
 
-	app.Get("/trivias", triviaHandler.GetAllTrivias)
-	app.Get("/trivias/:id", triviaHandler.GetTriviaByID)
-	app.Post("/trivias", triviaHandler.CreateTrivia)
-	app.Put("/trivias/:id", triviaHandler.UpdateTrivia)
-	app.Delete("/trivias/:id", triviaHandler.DeleteTrivia)
-
+	app.Get("/trivias", auth, triviaHandler.GetAllTrivias)
+	app.Get("/trivias/:id", auth, triviaHandler.GetTriviaByID)
+	app.Get("/trivias/:id/ranking", auth, triviaHandler.GetTriviaRanking)
+	app.Get("/trivias/:id/export", auth, authors, triviaHandler.ExportTrivia)
+	app.Get("/trivias/:id/review", auth, triviaHandler.GetTriviaReview)
+	app.Get("/trivias/:id/status/history", auth, authors, triviaHandler.GetTriviaStatusHistory)
+	app.Get("/trivias/:id/users/:userId/score", auth, middleware.RequireSelfOrRoles("userId", models.RoleAuthor, models.RoleAdmin), triviaHandler.GetUserScore)
+	app.Get("/users/:id/participations", auth, middleware.RequireSelfOrRoles("id", models.RoleAuthor, models.RoleAdmin), triviaHandler.GetUserParticipations)
+	app.Post("/trivias", auth, authors, idempotent, triviaHandler.CreateTrivia)
+	app.Post("/trivias/generate", auth, authors, idempotent, triviaHandler.GenerateTrivia)
+	app.Post("/trivias/import", auth, authors, idempotent, triviaHandler.ImportTrivia)
+	app.Post("/trivias/:id/status", auth, authors, triviaHandler.ChangeTriviaStatus)
+	app.Put("/trivias/:id", auth, authors, triviaHandler.UpdateTrivia)
+	app.Delete("/trivias/:id", auth, authors, triviaHandler.DeleteTrivia)
+	app.Post("/trivias/:id/restore", auth, admins, triviaHandler.RestoreTrivia)
+	app.Delete("/trivias/:id/purge", auth, admins, triviaHandler.PurgeTrivia)
+	app.Post("/trivias/:id/users/:userId", auth, authors, triviaHandler.AssignUser)
+	app.Delete("/trivias/:id/users/:userId", auth, authors, triviaHandler.UnassignUser)
+	app.Get("/author/trivias/:id", auth, authors, triviaHandler.GetTriviaByIDForAuthor)
 }
@@ -1,8 +1,13 @@
 package module
 
 import (
+	"context"
+	"fmt"
 	usecases "talana_prueba_tecnica/src/app/usecases/user_usecase"
+	"talana_prueba_tecnica/src/entity/models"
 	"talana_prueba_tecnica/src/infraestructure/handlers"
+	"talana_prueba_tecnica/src/infraestructure/middleware"
+	idempotencyrepository "talana_prueba_tecnica/src/infraestructure/repository/idempotency_repository"
 	repository "talana_prueba_tecnica/src/infraestructure/repository/user_repository"
 	"talana_prueba_tecnica/src/shared"
 
@@ -14,10 +19,28 @@ func UserModule(app *fiber.App) {
 	userRepo := repository.NewUserRepository(db)
 	userUseCase := usecases.NewUserUseCase(userRepo)
 	userHandler := handlers.NewUserHandler(userUseCase)
+	auth := middleware.Authenticate(userRepo)
+	admins := middleware.RequireRoles(models.RoleAdmin)
+	idempotent := middleware.Idempotency(idempotencyrepository.NewIdempotencyRepository(db))
 
-	app.Get("/users", userHandler.GetAllUsers)
-	app.Get("/users/:id", userHandler.GetUserByID)
-	app.Post("/users", userHandler.CreateUser)
-	app.Put("/users/:id", userHandler.UpdateUser)
-	app.Delete("/users/:id", userHandler.DeleteUser)
+	app.Get("/users", auth, admins, userHandler.GetAllUsers)
+	app.Get("/users/:id", auth, middleware.RequireSelfOrRoles("id", models.RoleAdmin), userHandler.GetUserByID)
+	app.Post("/users", auth, admins, idempotent, userHandler.CreateUser)
+	app.Put("/users/:id", auth, admins, userHandler.UpdateUser)
+	app.Delete("/users/:id", auth, admins, userHandler.DeleteUser)
+	app.Post("/users/:id/restore", auth, admins, userHandler.RestoreUser)
+	app.Delete("/users/:id/purge", auth, admins, userHandler.PurgeUser)
+	app.Post("/users/:id/roles/:role", auth, admins, userHandler.GrantRole)
+	app.Delete("/users/:id/roles/:role", auth, admins, userHandler.RevokeRole)
+}
+
+// GrantAdmin gives the admin role to the registered user with email. It
+// bootstraps the first admin of an installation, who grants the other roles.
+func GrantAdmin(ctx context.Context, email string) error {
+	userRepo := repository.NewUserRepository(shared.Init())
+	user, err := userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		return fmt.Errorf("finding user %s: %w", email, err)
+	}
+	return usecases.NewUserUseCase(userRepo).GrantRole(ctx, user.ID, models.RoleAdmin)
 }
@@ -0,0 +1,136 @@
+package authusecase
+
+import (
+	"context"
+	"errors"
+	"talana_prueba_tecnica/src/entity/models"
+	"talana_prueba_tecnica/src/entity/requests"
+	"talana_prueba_tecnica/src/entity/responses"
+	repository "talana_prueba_tecnica/src/infraestructure/repository/user_repository"
+	"talana_prueba_tecnica/src/shared"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidCredentials = errors.New("invalid email or password")
+	ErrEmailTaken         = errors.New("email already registered")
+)
+
+type AuthUseCase struct {
+	userRepository repository.UserRepositoryInterface
+}
+
+func NewAuthUseCase(userRepository repository.UserRepositoryInterface) *AuthUseCase {
+	return &AuthUseCase{
+		userRepository: userRepository,
+	}
+}
+
+func (u *AuthUseCase) Register(ctx context.Context, req *requests.RegisterUserRequest) (responses.UserResponse, error) {
+	log := logrus.WithContext(ctx)
+	log.Info("Register user usecase")
+
+	if req.Name == "" || req.Email == "" {
+		log.Error("Name and email are required")
+		return responses.UserResponse{}, errors.New("name and email are required")
+	}
+
+	_, err := u.userRepository.FindByEmail(ctx, req.Email)
+	if err == nil {
+		log.Errorf("Email %s already registered", req.Email)
+		return responses.UserResponse{}, ErrEmailTaken
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.WithError(err).Error("Error finding user by email")
+		return responses.UserResponse{}, err
+	}
+
+	passwordHash, err := shared.HashPassword(req.Password)
+	if err != nil {
+		log.WithError(err).Error("Invalid password")
+		return responses.UserResponse{}, err
+	}
+
+	user := models.UserModel{
+		Name:         req.Name,
+		Email:        req.Email,
+		Department:   req.Department,
+		PasswordHash: passwordHash,
+		Roles:        shared.InitialRoles(),
+	}
+	if err := u.userRepository.Create(ctx, &user); err != nil {
+		log.WithError(err).Error("Error creating user")
+		return responses.UserResponse{}, err
+	}
+
+	log.Info("User registered")
+	return responses.UserResponse{
+		ID:         user.ID,
+		Name:       user.Name,
+		Email:      user.Email,
+		Department: user.Department,
+		Roles:      user.RoleNames(),
+	}, nil
+}
+
+func (u *AuthUseCase) Login(ctx context.Context, req *requests.LoginRequest) (responses.TokenResponse, error) {
+	log := logrus.WithContext(ctx)
+	log.Info("Login usecase")
+
+	user, err := u.userRepository.FindByEmail(ctx, req.Email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Errorf("No user registered with email %s", req.Email)
+			return responses.TokenResponse{}, ErrInvalidCredentials
+		}
+		log.WithError(err).Error("Error finding user by email")
+		return responses.TokenResponse{}, err
+	}
+
+	if user.PasswordHash == "" || !shared.CheckPassword(user.PasswordHash, req.Password) {
+		log.Errorf("Invalid password for user ID %d", user.ID)
+		return responses.TokenResponse{}, ErrInvalidCredentials
+	}
+
+	log.Infof("User ID %d logged in", user.ID)
+	return issueTokens(user.ID)
+}
+
+func (u *AuthUseCase) Refresh(ctx context.Context, req *requests.RefreshTokenRequest) (responses.TokenResponse, error) {
+	log := logrus.WithContext(ctx)
+	log.Info("Refresh token usecase")
+
+	userID, err := shared.ParseToken(req.RefreshToken, shared.RefreshTokenType)
+	if err != nil {
+		log.WithError(err).Error("Invalid refresh token")
+		return responses.TokenResponse{}, err
+	}
+
+	if _, err := u.userRepository.FindByID(ctx, userID); err != nil {
+		log.WithError(err).Errorf("User ID %d not found", userID)
+		return responses.TokenResponse{}, shared.ErrInvalidToken
+	}
+
+	log.Infof("Tokens refreshed for user ID %d", userID)
+	return issueTokens(userID)
+}
+
+func issueTokens(userID uint) (responses.TokenResponse, error) {
+	accessToken, err := shared.GenerateToken(userID, shared.AccessTokenType, shared.AccessTokenTTL)
+	if err != nil {
+		return responses.TokenResponse{}, err
+	}
+	refreshToken, err := shared.GenerateToken(userID, shared.RefreshTokenType, shared.RefreshTokenTTL)
+	if err != nil {
+		return responses.TokenResponse{}, err
+	}
+
+	return responses.TokenResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(shared.AccessTokenTTL.Seconds()),
+	}, nil
+}
@@ -0,0 +1,13 @@
+package authusecase
+
+import (
+	"context"
+	"talana_prueba_tecnica/src/entity/requests"
+	"talana_prueba_tecnica/src/entity/responses"
+)
+
+type AuthUseCaseInterface interface {
+	Register(ctx context.Context, req *requests.RegisterUserRequest) (responses.UserResponse, error)
+	Login(ctx context.Context, req *requests.LoginRequest) (responses.TokenResponse, error)
+	Refresh(ctx context.Context, req *requests.RefreshTokenRequest) (responses.TokenResponse, error)
+}
@@ -0,0 +1,194 @@
+package categoryusecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"talana_prueba_tecnica/src/entity/models"
+	"talana_prueba_tecnica/src/entity/requests"
+	"talana_prueba_tecnica/src/entity/responses"
+	categoryrepository "talana_prueba_tecnica/src/infraestructure/repository/category_repository"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrCategoryNotFound    = errors.New("category not found")
+	ErrInvalidCategory     = errors.New("category requires a name of at most 100 characters")
+	ErrParentNotFound      = errors.New("parent category not found")
+	ErrCategoryCycle       = errors.New("a category cannot be nested under itself or its subcategories")
+	ErrCategoryHasChildren = errors.New("category has subcategories, move or delete them first")
+)
+
+const maxCategoryNameLength = 100
+
+type CategoryUseCase struct {
+	repository categoryrepository.CategoryRepositoryInterface
+}
+
+func NewCategoryUseCase(repository categoryrepository.CategoryRepositoryInterface) *CategoryUseCase {
+	return &CategoryUseCase{repository: repository}
+}
+
+// FindAll returns the category tree, top-level categories first.
+func (u *CategoryUseCase) FindAll(ctx context.Context) ([]responses.CategoryResponse, error) {
+	log := logrus.WithContext(ctx)
+	log.Info("Get all categories usecase")
+
+	categories, err := u.repository.FindAll(ctx)
+	if err != nil {
+		log.WithError(err).Error("Error finding categories")
+		return nil, err
+	}
+
+	children := map[uint][]models.Category{}
+	var roots []models.Category
+	for _, category := range categories {
+		if category.ParentID == nil {
+			roots = append(roots, category)
+			continue
+		}
+		children[*category.ParentID] = append(children[*category.ParentID], category)
+	}
+
+	var build func(categories []models.Category) []responses.CategoryResponse
+	build = func(categories []models.Category) []responses.CategoryResponse {
+		var result []responses.CategoryResponse
+		for _, category := range categories {
+			response := toCategoryResponse(&category)
+			response.Children = build(children[category.ID])
+			result = append(result, response)
+		}
+		return result
+	}
+
+	log.Info("Categories found")
+	return build(roots), nil
+}
+
+func (u *CategoryUseCase) FindByID(ctx context.Context, id uint) (responses.CategoryResponse, error) {
+	log := logrus.WithContext(ctx)
+	log.Infof("Get category by ID %d usecase", id)
+
+	category, err := u.findCategory(ctx, id)
+	if err != nil {
+		return responses.CategoryResponse{}, err
+	}
+
+	response := toCategoryResponse(category)
+	for _, child := range category.Children {
+		response.Children = append(response.Children, toCategoryResponse(&child))
+	}
+	return response, nil
+}
+
+func (u *CategoryUseCase) CreateCategory(ctx context.Context, req *requests.CreateCategoryRequest) (responses.CategoryResponse, error) {
+	log := logrus.WithContext(ctx)
+	log.Info("Create category usecase")
+
+	category := &models.Category{Name: strings.TrimSpace(req.Name), ParentID: req.ParentID}
+	if err := u.validate(ctx, category); err != nil {
+		log.WithError(err).Error("Invalid category")
+		return responses.CategoryResponse{}, err
+	}
+
+	if err := u.repository.CreateCategory(ctx, category); err != nil {
+		log.WithError(err).Error("Error creating category")
+		return responses.CategoryResponse{}, err
+	}
+
+	log.Info("Category created")
+	return toCategoryResponse(category), nil
+}
+
+func (u *CategoryUseCase) UpdateCategory(ctx context.Context, id uint, req *requests.CreateCategoryRequest) (responses.CategoryResponse, error) {
+	log := logrus.WithContext(ctx)
+	log.Infof("Update category ID %d usecase", id)
+
+	category, err := u.findCategory(ctx, id)
+	if err != nil {
+		return responses.CategoryResponse{}, err
+	}
+
+	category.Name = strings.TrimSpace(req.Name)
+	category.ParentID = req.ParentID
+	if err := u.validate(ctx, category); err != nil {
+		log.WithError(err).Error("Invalid category")
+		return responses.CategoryResponse{}, err
+	}
+
+	if err := u.repository.UpdateCategory(ctx, category); err != nil {
+		log.WithError(err).Error("Error updating category")
+		return responses.CategoryResponse{}, err
+	}
+
+	log.Info("Category updated")
+	return toCategoryResponse(category), nil
+}
+
+func (u *CategoryUseCase) DeleteCategory(ctx context.Context, id uint) error {
+	log := logrus.WithContext(ctx)
+	log.Infof("Delete category ID %d usecase", id)
+
+	if _, err := u.findCategory(ctx, id); err != nil {
+		return err
+	}
+
+	hasChildren, err := u.repository.HasChildren(ctx, id)
+	if err != nil {
+		return err
+	}
+	if hasChildren {
+		log.Errorf("Category ID %d has subcategories", id)
+		return ErrCategoryHasChildren
+	}
+
+	if err := u.repository.DeleteCategory(ctx, id); err != nil {
+		log.WithError(err).Error("Error deleting category")
+		return err
+	}
+
+	log.Info("Category deleted")
+	return nil
+}
+
+func (u *CategoryUseCase) findCategory(ctx context.Context, id uint) (*models.Category, error) {
+	category, err := u.repository.FindByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrCategoryNotFound
+	}
+	return category, err
+}
+
+// validate checks the name of category and that its parent exists without
+// creating a cycle.
+func (u *CategoryUseCase) validate(ctx context.Context, category *models.Category) error {
+	if category.Name == "" || len(category.Name) > maxCategoryNameLength {
+		return ErrInvalidCategory
+	}
+
+	for parentID := category.ParentID; parentID != nil; {
+		if category.ID != 0 && *parentID == category.ID {
+			return ErrCategoryCycle
+		}
+
+		parent, err := u.repository.FindByID(ctx, *parentID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrParentNotFound
+		}
+		if err != nil {
+			return err
+		}
+		parentID = parent.ParentID
+	}
+	return nil
+}
+
+func toCategoryResponse(category *models.Category) responses.CategoryResponse {
+	return responses.CategoryResponse{
+		ID:       category.ID,
+		Name:     category.Name,
+		ParentID: category.ParentID,
+	}
+}
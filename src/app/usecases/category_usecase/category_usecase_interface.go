@@ -0,0 +1,15 @@
+package categoryusecase
+
+import (
+	"context"
+	"talana_prueba_tecnica/src/entity/requests"
+	"talana_prueba_tecnica/src/entity/responses"
+)
+
+type CategoryUseCaseInterface interface {
+	FindAll(ctx context.Context) ([]responses.CategoryResponse, error)
+	FindByID(ctx context.Context, id uint) (responses.CategoryResponse, error)
+	CreateCategory(ctx context.Context, req *requests.CreateCategoryRequest) (responses.CategoryResponse, error)
+	UpdateCategory(ctx context.Context, id uint, req *requests.CreateCategoryRequest) (responses.CategoryResponse, error)
+	DeleteCategory(ctx context.Context, id uint) error
+}
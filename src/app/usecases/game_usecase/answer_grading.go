@@ -0,0 +1,142 @@
+package game_usecase
+
+import (
+	"math"
+	"strings"
+	"talana_prueba_tecnica/src/entity/models"
+	"talana_prueba_tecnica/src/entity/requests"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// numericEpsilon absorbs float rounding when comparing numeric answers.
+const numericEpsilon = 1e-9
+
+// gradeAnswer returns the share of the question earned by answer, from 0 to
+// 1. Only multi-select questions with partial credit earn shares in between.
+func gradeAnswer(question *models.Question, answer requests.AnswerRequest) float64 {
+	switch question.Type {
+	case models.QuestionMultiSelect:
+		return gradeMultiSelect(question, answer.SelectedOptions)
+	case models.QuestionNumeric:
+		if answer.NumericAnswer == nil || question.NumericAnswer == nil {
+			return 0
+		}
+		if math.Abs(*answer.NumericAnswer-*question.NumericAnswer) <= question.Tolerance+numericEpsilon {
+			return 1
+		}
+		return 0
+	case models.QuestionFreeText:
+		for _, option := range question.Options {
+			if textMatches(option.Text, answer.TextAnswer) {
+				return 1
+			}
+		}
+		return 0
+	}
+
+	if question.CorrectOption == answer.SelectedOption {
+		return 1
+	}
+	return 0
+}
+
+// gradeMultiSelect awards full credit only for the exact set of correct
+// options. With partial credit every correct option selected earns its share
+// and every wrong one takes a share away, never going below zero.
+func gradeMultiSelect(question *models.Question, selected []uint) float64 {
+	correct := 0
+	for _, option := range question.Options {
+		if option.IsCorrect {
+			correct++
+		}
+	}
+	if correct == 0 {
+		return 0
+	}
+
+	hits, misses := 0, 0
+	for _, index := range selected {
+		if int(index) < len(question.Options) && question.Options[index].IsCorrect {
+			hits++
+		} else {
+			misses++
+		}
+	}
+
+	if hits == correct && misses == 0 {
+		return 1
+	}
+	if !question.PartialCredit {
+		return 0
+	}
+	return math.Max(0, float64(hits-misses)/float64(correct))
+}
+
+// textMatches compares free text answers ignoring case, accents, punctuation
+// and extra spaces, tolerating one typo every five characters of the
+// accepted answer.
+func textMatches(accepted, given string) bool {
+	expected := []rune(normalizeText(accepted))
+	actual := []rune(normalizeText(given))
+	if len(actual) == 0 {
+		return false
+	}
+	return levenshtein(expected, actual) <= len(expected)/5
+}
+
+func normalizeText(text string) string {
+	stripAccents := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	normalized, _, err := transform.String(stripAccents, strings.ToLower(text))
+	if err != nil {
+		normalized = strings.ToLower(text)
+	}
+
+	normalized = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return ' '
+	}, normalized)
+	return strings.Join(strings.Fields(normalized), " ")
+}
+
+func levenshtein(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+// newAnswer keeps the part of answer matching the question type.
+func newAnswer(question *models.Question, answer requests.AnswerRequest) *models.Answer {
+	result := &models.Answer{QuestionID: answer.QuestionID, QuestionRevision: question.Revision}
+	switch question.Type {
+	case models.QuestionMultiSelect:
+		result.SelectedOptions = answer.SelectedOptions
+	case models.QuestionNumeric:
+		result.NumericAnswer = answer.NumericAnswer
+	case models.QuestionFreeText:
+		result.TextAnswer = strings.TrimSpace(answer.TextAnswer)
+	default:
+		result.SelectedOption = answer.SelectedOption
+	}
+	return result
+}
@@ -0,0 +1,59 @@
+package game_usecase
+
+import (
+	"math"
+	"talana_prueba_tecnica/src/entity/models"
+	"talana_prueba_tecnica/src/entity/requests"
+	"testing"
+)
+
+func TestGradeAnswer(t *testing.T) {
+	numeric := func(value float64) *float64 { return &value }
+
+	single := &models.Question{
+		Type:          models.QuestionSingleChoice,
+		CorrectOption: 1,
+		Options:       []models.Option{{ID: 500, Text: "Lima"}, {ID: 501, Text: "Santiago"}},
+	}
+	multi := &models.Question{
+		Type: models.QuestionMultiSelect,
+		Options: []models.Option{
+			{Text: "Go", IsCorrect: true},
+			{Text: "Rust", IsCorrect: true},
+			{Text: "HTML"},
+		},
+	}
+	partial := *multi
+	partial.PartialCredit = true
+	number := &models.Question{Type: models.QuestionNumeric, NumericAnswer: numeric(3.14), Tolerance: 0.01}
+	text := &models.Question{Type: models.QuestionFreeText, Options: []models.Option{{Text: "Valparaíso"}}}
+
+	tests := []struct {
+		name     string
+		question *models.Question
+		answer   requests.AnswerRequest
+		want     float64
+	}{
+		{"correct option index", single, requests.AnswerRequest{SelectedOption: 1}, 1},
+		{"wrong option index", single, requests.AnswerRequest{SelectedOption: 0}, 0},
+		{"correct option ID", single, requests.AnswerRequest{SelectedOption: 501}, 0},
+		{"exact multi-select", multi, requests.AnswerRequest{SelectedOptions: []uint{1, 0}}, 1},
+		{"incomplete multi-select", multi, requests.AnswerRequest{SelectedOptions: []uint{0}}, 0},
+		{"partial credit for a correct option", &partial, requests.AnswerRequest{SelectedOptions: []uint{0}}, 0.5},
+		{"partial credit taken by a wrong option", &partial, requests.AnswerRequest{SelectedOptions: []uint{0, 2}}, 0},
+		{"partial credit for an out of range option", &partial, requests.AnswerRequest{SelectedOptions: []uint{0, 1, 7}}, 0.5},
+		{"numeric within tolerance", number, requests.AnswerRequest{NumericAnswer: numeric(3.15)}, 1},
+		{"numeric out of tolerance", number, requests.AnswerRequest{NumericAnswer: numeric(3.2)}, 0},
+		{"missing numeric answer", number, requests.AnswerRequest{}, 0},
+		{"free text without accents or case", text, requests.AnswerRequest{TextAnswer: " VALPARAISO "}, 1},
+		{"free text with a typo", text, requests.AnswerRequest{TextAnswer: "valparaizo"}, 1},
+		{"different free text", text, requests.AnswerRequest{TextAnswer: "Viña del Mar"}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := gradeAnswer(tt.question, tt.answer); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("gradeAnswer = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package game_usecase
+
+import (
+	"fmt"
+	"strings"
+	"talana_prueba_tecnica/src/entity/models"
+	"talana_prueba_tecnica/src/entity/requests"
+	"talana_prueba_tecnica/src/entity/responses"
+)
+
+const (
+	ValidationUnknownQuestion = "unknown_question"
+	ValidationForeignOption   = "foreign_option"
+	ValidationDuplicateAnswer = "duplicate_answer"
+	ValidationMissingAnswer   = "missing_answer"
+	ValidationInvalidAnswer   = "invalid_answer"
+)
+
+// AnswerValidationError lists every problem found in a submission so clients
+// can fix them all at once.
+type AnswerValidationError struct {
+	Fields []responses.FieldError
+}
+
+func (e *AnswerValidationError) Error() string {
+	return fmt.Sprintf("invalid answers: %d problem(s) found", len(e.Fields))
+}
+
+// validateAnswers checks that every answer targets a question of the trivia
+// with one of its options, that no question is answered twice and, when the
+// trivia requires it, that every question is answered.
+func validateAnswers(trivia *models.Trivia, answers []requests.AnswerRequest) error {
+	questions := make(map[uint]*models.Question, len(trivia.Questions))
+	for i := range trivia.Questions {
+		questions[trivia.Questions[i].ID] = &trivia.Questions[i]
+	}
+
+	var fields []responses.FieldError
+	answered := map[uint]bool{}
+	for i, answer := range answers {
+		question, ok := questions[answer.QuestionID]
+		switch {
+		case !ok:
+			fields = append(fields, responses.FieldError{
+				Field:      fmt.Sprintf("responses[%d].question_id", i),
+				QuestionID: answer.QuestionID,
+				Code:       ValidationUnknownQuestion,
+				Message:    "question is not part of this trivia",
+			})
+		case answered[answer.QuestionID]:
+			fields = append(fields, responses.FieldError{
+				Field:      fmt.Sprintf("responses[%d].question_id", i),
+				QuestionID: answer.QuestionID,
+				Code:       ValidationDuplicateAnswer,
+				Message:    "question answered more than once",
+			})
+		default:
+			if fieldErr := answerShapeError(question, answer); fieldErr != nil {
+				fieldErr.Field = fmt.Sprintf("responses[%d].%s", i, fieldErr.Field)
+				fields = append(fields, *fieldErr)
+			}
+		}
+		answered[answer.QuestionID] = true
+	}
+
+	if trivia.RequireAllAnswers {
+		for _, question := range trivia.Questions {
+			if !answered[question.ID] {
+				fields = append(fields, responses.FieldError{
+					Field:      "responses",
+					QuestionID: question.ID,
+					Code:       ValidationMissingAnswer,
+					Message:    "question must be answered",
+				})
+			}
+		}
+	}
+
+	if len(fields) > 0 {
+		return &AnswerValidationError{Fields: fields}
+	}
+	return nil
+}
+
+// answerShapeError checks that answer has the shape of the question type,
+// returning the problem found for its field, if any.
+func answerShapeError(question *models.Question, answer requests.AnswerRequest) *responses.FieldError {
+	fieldErr := func(field, code, message string) *responses.FieldError {
+		return &responses.FieldError{Field: field, QuestionID: question.ID, Code: code, Message: message}
+	}
+
+	switch question.Type {
+	case models.QuestionMultiSelect:
+		if len(answer.SelectedOptions) == 0 {
+			return fieldErr("selected_options", ValidationInvalidAnswer, "at least one option must be selected")
+		}
+		selected := map[uint]bool{}
+		for _, option := range answer.SelectedOptions {
+			if !isValidOption(question, option) {
+				return fieldErr("selected_options", ValidationForeignOption, "selected option does not belong to the question")
+			}
+			if selected[option] {
+				return fieldErr("selected_options", ValidationInvalidAnswer, "option selected more than once")
+			}
+			selected[option] = true
+		}
+	case models.QuestionNumeric:
+		if answer.NumericAnswer == nil {
+			return fieldErr("numeric_answer", ValidationInvalidAnswer, "numeric answer is required")
+		}
+	case models.QuestionFreeText:
+		if strings.TrimSpace(answer.TextAnswer) == "" {
+			return fieldErr("text_answer", ValidationInvalidAnswer, "text answer is required")
+		}
+	default:
+		if !isValidOption(question, answer.SelectedOption) {
+			return fieldErr("selected_option", ValidationForeignOption, "selected option does not belong to the question")
+		}
+	}
+	return nil
+}
+
+// isValidOption reports whether selected is the index of one of the question
+// options in their stored order: the OptionResponse.Index of the option when
+// options are not shuffled, as unshuffleAnswer maps them back otherwise, and
+// the value Question.CorrectOption holds. Option IDs are not accepted.
+func isValidOption(question *models.Question, selected uint) bool {
+	return int(selected) < len(question.Options)
+}
@@ -0,0 +1,129 @@
+package game_usecase
+
+import (
+	"errors"
+	"talana_prueba_tecnica/src/entity/models"
+	"talana_prueba_tecnica/src/entity/requests"
+	"testing"
+)
+
+func TestIsValidOption(t *testing.T) {
+	question := &models.Question{
+		ID:      40,
+		Type:    models.QuestionSingleChoice,
+		Options: []models.Option{{ID: 400, Text: "A"}, {ID: 401, Text: "B"}, {ID: 402, Text: "C"}},
+	}
+
+	tests := []struct {
+		name     string
+		selected uint
+		want     bool
+	}{
+		{"first index", 0, true},
+		{"last index", 2, true},
+		{"past the last index", 3, false},
+		{"option ID", 401, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isValidOption(question, tt.selected); got != tt.want {
+				t.Errorf("isValidOption(%d) = %v, want %v", tt.selected, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateAnswers(t *testing.T) {
+	numeric := 3.0
+	trivia := &models.Trivia{
+		Questions: []models.Question{
+			{ID: 1, Type: models.QuestionSingleChoice, Options: []models.Option{{Text: "A"}, {Text: "B"}}},
+			{ID: 2, Type: models.QuestionMultiSelect, Options: []models.Option{{Text: "A"}, {Text: "B"}, {Text: "C"}}},
+			{ID: 3, Type: models.QuestionNumeric},
+			{ID: 4, Type: models.QuestionFreeText},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		requireAll bool
+		answers    []requests.AnswerRequest
+		want       []string
+	}{
+		{
+			name: "valid answers",
+			answers: []requests.AnswerRequest{
+				{QuestionID: 1, SelectedOption: 1},
+				{QuestionID: 2, SelectedOptions: []uint{0, 2}},
+				{QuestionID: 3, NumericAnswer: &numeric},
+				{QuestionID: 4, TextAnswer: "Santiago"},
+			},
+		},
+		{
+			name:    "unknown question",
+			answers: []requests.AnswerRequest{{QuestionID: 9}},
+			want:    []string{ValidationUnknownQuestion},
+		},
+		{
+			name:    "option out of range",
+			answers: []requests.AnswerRequest{{QuestionID: 1, SelectedOption: 2}},
+			want:    []string{ValidationForeignOption},
+		},
+		{
+			name:    "question answered twice",
+			answers: []requests.AnswerRequest{{QuestionID: 1}, {QuestionID: 1, SelectedOption: 1}},
+			want:    []string{ValidationDuplicateAnswer},
+		},
+		{
+			name:    "option selected twice",
+			answers: []requests.AnswerRequest{{QuestionID: 2, SelectedOptions: []uint{1, 1}}},
+			want:    []string{ValidationInvalidAnswer},
+		},
+		{
+			name:    "no option selected",
+			answers: []requests.AnswerRequest{{QuestionID: 2}},
+			want:    []string{ValidationInvalidAnswer},
+		},
+		{
+			name:    "missing numeric answer",
+			answers: []requests.AnswerRequest{{QuestionID: 3}},
+			want:    []string{ValidationInvalidAnswer},
+		},
+		{
+			name:    "blank text answer",
+			answers: []requests.AnswerRequest{{QuestionID: 4, TextAnswer: "  "}},
+			want:    []string{ValidationInvalidAnswer},
+		},
+		{
+			name:       "missing answers when all are required",
+			requireAll: true,
+			answers:    []requests.AnswerRequest{{QuestionID: 1}, {QuestionID: 3, NumericAnswer: &numeric}},
+			want:       []string{ValidationMissingAnswer, ValidationMissingAnswer},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trivia.RequireAllAnswers = tt.requireAll
+
+			err := validateAnswers(trivia, tt.answers)
+			var codes []string
+			var validationErr *AnswerValidationError
+			if errors.As(err, &validationErr) {
+				for _, field := range validationErr.Fields {
+					codes = append(codes, field.Code)
+				}
+			} else if err != nil {
+				t.Fatalf("validateAnswers: %v", err)
+			}
+
+			if len(codes) != len(tt.want) {
+				t.Fatalf("validation codes %v, want %v", codes, tt.want)
+			}
+			for i := range codes {
+				if codes[i] != tt.want[i] {
+					t.Fatalf("validation codes %v, want %v", codes, tt.want)
+				}
+			}
+		})
+	}
+}
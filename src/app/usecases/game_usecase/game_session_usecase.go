@@ -0,0 +1,624 @@
+package game_usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"talana_prueba_tecnica/src/entity/models"
+	"talana_prueba_tecnica/src/entity/requests"
+	"talana_prueba_tecnica/src/entity/responses"
+	"talana_prueba_tecnica/src/shared"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrSessionNotFound    = errors.New("session not found")
+	ErrSessionFinished    = errors.New("session already finished")
+	ErrQuestionNotServed  = errors.New("question has not been served yet")
+	ErrUnexpectedQuestion = errors.New("question is not the current question of the session")
+	ErrAnswerTooLate      = errors.New("answer submitted after the question time limit")
+	ErrAlreadyAnswered    = errors.New("question already answered")
+	ErrTimeLimitExceeded  = errors.New("trivia time limit exceeded")
+	ErrNotAssigned        = errors.New("user is not assigned to this trivia")
+	ErrMaxAttemptsReached = errors.New("maximum number of attempts reached for this trivia")
+	ErrAttemptCooldown    = errors.New("must wait before attempting this trivia again")
+
+	ErrTimedTriviaRequiresSession = errors.New("trivia is time limited and must be played through a game session")
+	ErrIdempotencyKeyReused       = errors.New("idempotency key was already used for a different trivia")
+	ErrTriviaNotPublished         = errors.New("trivia is not published")
+)
+
+// answerGracePeriod absorbs network latency when enforcing time limits.
+const answerGracePeriod = 2 * time.Second
+
+func (u *GameUseCase) StartSession(ctx context.Context, triviaID uint, req *requests.StartSessionRequest) (responses.GameSessionResponse, error) {
+	log := logrus.WithContext(ctx)
+	log.Infof("Starting session for trivia ID %d and user ID %d usecase", triviaID, req.UserID)
+
+	if req.UserID == 0 {
+		log.Error("No user provided")
+		return responses.GameSessionResponse{}, errors.New("user_id is required")
+	}
+
+	trivia, err := u.findPlayableTrivia(ctx, triviaID)
+	if err != nil {
+		return responses.GameSessionResponse{}, err
+	}
+
+	session, err := u.prepareSession(ctx, &trivia, req.UserID)
+	if err != nil {
+		return responses.GameSessionResponse{}, err
+	}
+	if session.ID != 0 {
+		return toSessionResponse(session, &trivia), nil
+	}
+
+	if err := u.sessionRepo.CreateSession(ctx, session); err != nil {
+		log.WithError(err).Error("Error creating session")
+		return responses.GameSessionResponse{}, err
+	}
+
+	log.Infof("Session ID %d started", session.ID)
+	return toSessionResponse(session, &trivia), nil
+}
+
+// StartSessions starts or resumes the sessions of several users at once. Every
+// user is checked before any session is created, and the new sessions are
+// created in a single transaction, so either all of them start or none does.
+func (u *GameUseCase) StartSessions(ctx context.Context, triviaID uint, userIDs []uint) ([]responses.GameSessionResponse, error) {
+	log := logrus.WithContext(ctx)
+	log.Infof("Starting sessions for trivia ID %d and user IDs %v usecase", triviaID, userIDs)
+
+	trivia, err := u.findPlayableTrivia(ctx, triviaID)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]*models.GameSession, 0, len(userIDs))
+	for _, userID := range userIDs {
+		session, err := u.prepareSession(ctx, &trivia, userID)
+		if err != nil {
+			return nil, fmt.Errorf("user ID %d: %w", userID, err)
+		}
+		sessions = append(sessions, session)
+	}
+
+	err = u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		for _, session := range sessions {
+			if session.ID != 0 {
+				continue
+			}
+			if err := u.sessionRepo.CreateSession(ctx, session); err != nil {
+				log.WithError(err).Errorf("Error creating session for user ID %d", session.UserID)
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]responses.GameSessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		response = append(response, toSessionResponse(session, &trivia))
+	}
+
+	log.Infof("%d sessions started", len(response))
+	return response, nil
+}
+
+// prepareSession checks that userID may play trivia and returns the session
+// to play it with: the active session when there is one to resume, or a new
+// session that still has to be created.
+func (u *GameUseCase) prepareSession(ctx context.Context, trivia *models.Trivia, userID uint) (*models.GameSession, error) {
+	log := logrus.WithContext(ctx)
+
+	if err := u.ensureAssigned(ctx, trivia.ID, userID); err != nil {
+		return nil, err
+	}
+
+	active, err := u.sessionRepo.FindActive(ctx, trivia.ID, userID)
+	if err == nil {
+		err := u.enforceTriviaDeadline(ctx, active, trivia)
+		if err == nil {
+			log.Infof("Resuming active session ID %d", active.ID)
+			return active, nil
+		}
+		if !errors.Is(err, ErrTimeLimitExceeded) {
+			return nil, err
+		}
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.WithError(err).Error("Error looking for active session")
+		return nil, err
+	}
+
+	if err := u.ensureAttemptAllowed(ctx, trivia, userID); err != nil {
+		return nil, err
+	}
+
+	seed, err := u.attemptSeed(ctx, trivia, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	questions, err := u.repository.GetQuestionsForTrivia(ctx, trivia.ID)
+	if err != nil {
+		log.WithError(err).Error("Error getting questions for trivia")
+		return nil, err
+	}
+	if len(questions) == 0 {
+		log.Error("Trivia has no questions")
+		return nil, errors.New("trivia has no questions")
+	}
+
+	now := time.Now()
+	session := &models.GameSession{
+		UserID:    userID,
+		TriviaID:  trivia.ID,
+		Status:    models.SessionStatusActive,
+		StartedAt: now,
+		Participation: models.Participation{
+			UserID:      userID,
+			TriviaID:    trivia.ID,
+			ShuffleSeed: seed,
+			StartedAt:   &now,
+		},
+	}
+	for i, question := range arrangeQuestions(trivia, questions, seed) {
+		session.Questions = append(session.Questions, models.SessionQuestion{
+			QuestionID: question.ID,
+			Position:   i + 1,
+		})
+	}
+	return session, nil
+}
+
+func (u *GameUseCase) NextQuestion(ctx context.Context, triviaID, sessionID uint) (responses.SessionQuestionResponse, error) {
+	log := logrus.WithContext(ctx)
+	log.Infof("Getting next question for session ID %d usecase", sessionID)
+
+	session, err := u.findSession(ctx, triviaID, sessionID)
+	if err != nil {
+		return responses.SessionQuestionResponse{}, err
+	}
+
+	trivia, err := u.findPlayableTrivia(ctx, triviaID)
+	if err != nil {
+		return responses.SessionQuestionResponse{}, err
+	}
+
+	if err := u.enforceTriviaDeadline(ctx, session, &trivia); err != nil {
+		return responses.SessionQuestionResponse{}, err
+	}
+
+	for {
+		current := currentSessionQuestion(session)
+		if current == nil {
+			log.Error("Session already finished")
+			return responses.SessionQuestionResponse{}, ErrSessionFinished
+		}
+
+		question, err := u.servedQuestion(ctx, current)
+		if err != nil {
+			log.WithError(err).Errorf("Question ID %d not found", current.QuestionID)
+			return responses.SessionQuestionResponse{}, err
+		}
+
+		if current.ServedAt == nil {
+			current.QuestionRevision = question.Revision
+			if err := u.sessionRepo.MarkServed(ctx, current); err != nil {
+				log.WithError(err).Error("Error marking question as served")
+				return responses.SessionQuestionResponse{}, err
+			}
+		} else if limit := questionTimeLimit(question); limit > 0 && time.Since(*current.ServedAt) > limit+answerGracePeriod {
+			log.Infof("Question ID %d expired without an answer", question.ID)
+			if err := u.recordTimeout(ctx, session, current); err != nil {
+				return responses.SessionQuestionResponse{}, err
+			}
+			continue
+		}
+
+		log.Infof("Serving question ID %d", question.ID)
+		response := responses.SessionQuestionResponse{
+			SessionID:      session.ID,
+			Position:       current.Position,
+			TotalQuestions: len(session.Questions),
+			Question:       toPlayQuestionResponse(&trivia, question, session.Participation.ShuffleSeed),
+			ServedAt:       current.ServedAt,
+		}
+		if limit := questionTimeLimit(question); limit > 0 {
+			expiresAt := current.ServedAt.Add(limit)
+			response.ExpiresAt = &expiresAt
+		}
+		return response, nil
+	}
+}
+
+func (u *GameUseCase) AnswerQuestion(ctx context.Context, triviaID, sessionID uint, req *requests.SessionAnswerRequest) (responses.SessionAnswerResponse, error) {
+	log := logrus.WithContext(ctx)
+	log.Infof("Answering question ID %d for session ID %d usecase", req.QuestionID, sessionID)
+
+	session, err := u.findSession(ctx, triviaID, sessionID)
+	if err != nil {
+		return responses.SessionAnswerResponse{}, err
+	}
+
+	trivia, err := u.findPlayableTrivia(ctx, triviaID)
+	if err != nil {
+		return responses.SessionAnswerResponse{}, err
+	}
+
+	strategy, err := NewScoringStrategy(trivia.ScoringStrategy)
+	if err != nil {
+		log.WithError(err).Error("Invalid scoring strategy")
+		return responses.SessionAnswerResponse{}, err
+	}
+
+	if err := u.enforceTriviaDeadline(ctx, session, &trivia); err != nil {
+		return responses.SessionAnswerResponse{}, err
+	}
+
+	current := currentSessionQuestion(session)
+	if current == nil {
+		log.Error("Session already finished")
+		return responses.SessionAnswerResponse{}, ErrSessionFinished
+	}
+	if current.QuestionID != req.QuestionID {
+		log.Errorf("Question ID %d is not the current question", req.QuestionID)
+		return responses.SessionAnswerResponse{}, ErrUnexpectedQuestion
+	}
+	if current.ServedAt == nil {
+		log.Errorf("Question ID %d has not been served", req.QuestionID)
+		return responses.SessionAnswerResponse{}, ErrQuestionNotServed
+	}
+
+	question, err := u.servedQuestion(ctx, current)
+	if err != nil {
+		log.WithError(err).Errorf("Question ID %d not found", current.QuestionID)
+		return responses.SessionAnswerResponse{}, err
+	}
+
+	given := unshuffleAnswer(&trivia, question, requests.AnswerRequest(*req), session.Participation.ShuffleSeed)
+	if fieldErr := answerShapeError(question, given); fieldErr != nil {
+		log.Errorf("Invalid answer for question ID %d: %s", question.ID, fieldErr.Message)
+		return responses.SessionAnswerResponse{}, &AnswerValidationError{Fields: []responses.FieldError{*fieldErr}}
+	}
+
+	now := time.Now()
+	elapsed := now.Sub(*current.ServedAt)
+	limit := questionTimeLimit(question)
+	if limit > 0 && elapsed > limit+answerGracePeriod {
+		log.Errorf("Answer for question ID %d arrived after %s", question.ID, elapsed)
+		if err := u.recordTimeout(ctx, session, current); err != nil {
+			return responses.SessionAnswerResponse{}, err
+		}
+		return responses.SessionAnswerResponse{}, ErrAnswerTooLate
+	}
+
+	outcome := newAnswerOutcome(gradeAnswer(question, given))
+	outcome.Elapsed = elapsed
+	outcome.TimeLimit = limit
+	isCorrect := outcome.IsCorrect
+	points := strategy.Score(question, outcome)
+
+	answer := newAnswer(question, given)
+	answer.QuestionRevision = current.QuestionRevision
+	answer.IsCorrect = isCorrect
+	answer.Points = points
+	answer.ServedAt = current.ServedAt
+	answer.AnsweredAt = &now
+	session.Participation.Score += points
+	if current.Position == len(session.Questions) {
+		session.Status = models.SessionStatusCompleted
+	}
+
+	if err := u.sessionRepo.RecordAnswer(ctx, session, current, answer); err != nil {
+		log.WithError(err).Error("Error recording answer")
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return responses.SessionAnswerResponse{}, ErrAlreadyAnswered
+		}
+		return responses.SessionAnswerResponse{}, err
+	}
+	session.Participation.Answers = append(session.Participation.Answers, *answer)
+
+	log.Infof("Answer recorded for session ID %d", session.ID)
+	response := responses.SessionAnswerResponse{
+		QuestionID:     req.QuestionID,
+		IsCorrect:      isCorrect,
+		Points:         points,
+		ElapsedSeconds: elapsed.Seconds(),
+		Explanation:    question.Explanation,
+		ReferenceURL:   question.ReferenceURL,
+		Finished:       session.Status != models.SessionStatusActive,
+		Session:        toSessionResponse(session, &trivia),
+	}
+	if response.Finished {
+		result := toSessionResult(session, &trivia)
+		response.Result = &result
+	}
+	return response, nil
+}
+
+func (u *GameUseCase) SkipQuestion(ctx context.Context, triviaID, sessionID uint) error {
+	log := logrus.WithContext(ctx)
+	log.Infof("Skipping current question for session ID %d usecase", sessionID)
+
+	session, err := u.findSession(ctx, triviaID, sessionID)
+	if err != nil {
+		return err
+	}
+
+	current := currentSessionQuestion(session)
+	if current == nil {
+		log.Error("Session already finished")
+		return ErrSessionFinished
+	}
+	if current.ServedAt == nil {
+		log.Errorf("Question ID %d has not been served", current.QuestionID)
+		return ErrQuestionNotServed
+	}
+
+	if err := u.recordTimeout(ctx, session, current); err != nil {
+		return err
+	}
+
+	log.Infof("Question ID %d skipped", current.QuestionID)
+	return nil
+}
+
+// ensureAssigned returns ErrNotAssigned unless userID was assigned to the
+// trivia through its trivia_users list.
+func (u *GameUseCase) ensureAssigned(ctx context.Context, triviaID, userID uint) error {
+	log := logrus.WithContext(ctx)
+
+	assigned, err := u.triviaRepo.IsUserAssigned(ctx, triviaID, userID)
+	if err != nil {
+		log.WithError(err).Error("Error checking trivia assignment")
+		return err
+	}
+	if !assigned {
+		log.Errorf("User ID %d is not assigned to trivia ID %d", userID, triviaID)
+		return ErrNotAssigned
+	}
+	return nil
+}
+
+// ensureAttemptAllowed enforces the attempt limit and the cool-down between
+// attempts configured on the trivia.
+func (u *GameUseCase) ensureAttemptAllowed(ctx context.Context, trivia *models.Trivia, userID uint) error {
+	log := logrus.WithContext(ctx)
+
+	if trivia.MaxAttempts > 0 {
+		attempts, err := u.triviaRepo.CountAttempts(ctx, trivia.ID, userID)
+		if err != nil {
+			log.WithError(err).Error("Error counting attempts")
+			return err
+		}
+		if attempts >= int64(trivia.MaxAttempts) {
+			log.Errorf("User ID %d already used %d attempts", userID, attempts)
+			return ErrMaxAttemptsReached
+		}
+	}
+
+	if trivia.CooldownSeconds > 0 {
+		last, err := u.triviaRepo.FindLastAttempt(ctx, trivia.ID, userID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			log.WithError(err).Error("Error finding last attempt")
+			return err
+		}
+
+		lastAt := last.StartedAt
+		if last.FinishedAt != nil {
+			lastAt = last.FinishedAt
+		}
+		if lastAt != nil {
+			remaining := time.Until(lastAt.Add(time.Duration(trivia.CooldownSeconds) * time.Second))
+			if remaining > 0 {
+				log.Errorf("User ID %d is in cool-down for %s", userID, remaining)
+				return fmt.Errorf("%w, retry in %s", ErrAttemptCooldown, remaining.Round(time.Second))
+			}
+		}
+	}
+
+	return nil
+}
+
+func (u *GameUseCase) findSession(ctx context.Context, triviaID, sessionID uint) (*models.GameSession, error) {
+	log := logrus.WithContext(ctx)
+
+	session, err := u.sessionRepo.FindByID(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Errorf("Session ID %d not found", sessionID)
+			return nil, ErrSessionNotFound
+		}
+		log.WithError(err).Error("Error finding session")
+		return nil, err
+	}
+
+	if session.TriviaID != triviaID {
+		log.Errorf("Session ID %d does not belong to trivia ID %d", sessionID, triviaID)
+		return nil, ErrSessionNotFound
+	}
+
+	if user, ok := shared.CurrentUser(ctx); ok && session.UserID != user.ID {
+		log.Errorf("Session ID %d does not belong to user ID %d", sessionID, user.ID)
+		return nil, ErrSessionNotFound
+	}
+
+	return session, nil
+}
+
+// servedQuestion returns the question of current as it was when served, so
+// edits made afterwards change neither what the player sees nor how the
+// answer is graded. Questions not served yet are returned as they are now.
+func (u *GameUseCase) servedQuestion(ctx context.Context, current *models.SessionQuestion) (*models.Question, error) {
+	question, err := u.questionRepo.FindByID(ctx, current.QuestionID)
+	if err != nil {
+		return nil, err
+	}
+	if current.ServedAt == nil || question.Revision == current.QuestionRevision {
+		return question, nil
+	}
+
+	revision, err := u.triviaRepo.FindQuestionRevision(ctx, current.QuestionID, current.QuestionRevision)
+	if err != nil {
+		return nil, fmt.Errorf("revision %d of question ID %d: %w", current.QuestionRevision, current.QuestionID, err)
+	}
+	served := revision.Question()
+	return &served, nil
+}
+
+func currentSessionQuestion(session *models.GameSession) *models.SessionQuestion {
+	if session.Status != models.SessionStatusActive {
+		return nil
+	}
+	for i := range session.Questions {
+		if session.Questions[i].AnsweredAt == nil {
+			return &session.Questions[i]
+		}
+	}
+	return nil
+}
+
+func (u *GameUseCase) enforceTriviaDeadline(ctx context.Context, session *models.GameSession, trivia *models.Trivia) error {
+	log := logrus.WithContext(ctx)
+
+	deadline := sessionDeadline(session, trivia)
+	if session.Status != models.SessionStatusActive || deadline == nil || time.Now().Before(deadline.Add(answerGracePeriod)) {
+		return nil
+	}
+
+	log.Infof("Session ID %d exceeded the trivia time limit", session.ID)
+	session.Status = models.SessionStatusExpired
+	if err := u.sessionRepo.ExpireSession(ctx, session); err != nil {
+		log.WithError(err).Error("Error expiring session")
+		return err
+	}
+	return ErrTimeLimitExceeded
+}
+
+func (u *GameUseCase) recordTimeout(ctx context.Context, session *models.GameSession, current *models.SessionQuestion) error {
+	log := logrus.WithContext(ctx)
+
+	now := time.Now()
+	answer := &models.Answer{
+		QuestionID:       current.QuestionID,
+		QuestionRevision: current.QuestionRevision,
+		TimedOut:         true,
+		ServedAt:         current.ServedAt,
+		AnsweredAt:       &now,
+	}
+	if current.Position == len(session.Questions) {
+		session.Status = models.SessionStatusCompleted
+	}
+
+	if err := u.sessionRepo.RecordAnswer(ctx, session, current, answer); err != nil {
+		log.WithError(err).Error("Error recording timed out answer")
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrAlreadyAnswered
+		}
+		return err
+	}
+	session.Participation.Answers = append(session.Participation.Answers, *answer)
+	return nil
+}
+
+func toSessionResponse(session *models.GameSession, trivia *models.Trivia) responses.GameSessionResponse {
+	var answered int
+	for _, question := range session.Questions {
+		if question.AnsweredAt != nil {
+			answered++
+		}
+	}
+
+	var correctAnswers int
+	for _, answer := range session.Participation.Answers {
+		if answer.IsCorrect {
+			correctAnswers++
+		}
+	}
+
+	return responses.GameSessionResponse{
+		ID:                session.ID,
+		TriviaID:          session.TriviaID,
+		UserID:            session.UserID,
+		Status:            session.Status,
+		Score:             session.Participation.Score,
+		CorrectAnswers:    correctAnswers,
+		AnsweredQuestions: answered,
+		TotalQuestions:    len(session.Questions),
+		StartedAt:         session.StartedAt,
+		ExpiresAt:         sessionDeadline(session, trivia),
+		FinishedAt:        session.FinishedAt,
+	}
+}
+
+func toSessionResult(session *models.GameSession, trivia *models.Trivia) responses.SubmitAnswersResponse {
+	summary := toSessionResponse(session, trivia)
+
+	var elapsed time.Duration
+	if session.FinishedAt != nil {
+		elapsed = session.FinishedAt.Sub(session.StartedAt)
+	}
+
+	return responses.SubmitAnswersResponse{
+		TriviaID:        session.TriviaID,
+		UserID:          session.UserID,
+		ScoringStrategy: trivia.ScoringStrategy,
+		CorrectAnswers:  summary.CorrectAnswers,
+		TotalQuestions:  summary.TotalQuestions,
+		Score:           summary.Score,
+		ElapsedSeconds:  elapsed.Seconds(),
+	}
+}
+
+func sessionDeadline(session *models.GameSession, trivia *models.Trivia) *time.Time {
+	if trivia.TimeLimitSeconds <= 0 {
+		return nil
+	}
+	deadline := session.StartedAt.Add(time.Duration(trivia.TimeLimitSeconds) * time.Second)
+	return &deadline
+}
+
+func questionTimeLimit(question *models.Question) time.Duration {
+	return time.Duration(question.TimeLimitSeconds) * time.Second
+}
+
+func isTimeLimited(trivia *models.Trivia) bool {
+	if trivia.TimeLimitSeconds > 0 {
+		return true
+	}
+	for _, question := range trivia.Questions {
+		if question.TimeLimitSeconds > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func toPlayQuestionResponse(trivia *models.Trivia, question *models.Question, seed int64) responses.QuestionResponse {
+	var options []responses.OptionResponse
+	for i, option := range shownOptions(trivia, question, seed) {
+		options = append(options, responses.OptionResponse{
+			Index:  i,
+			Option: option.Text,
+		})
+	}
+
+	return responses.QuestionResponse{
+		ID:               question.ID,
+		Question:         question.Question,
+		Type:             question.Type,
+		Options:          options,
+		Difficulty:       question.Difficulty,
+		TimeLimitSeconds: question.TimeLimitSeconds,
+	}
+}
@@ -0,0 +1,220 @@
+package game_usecase
+
+import (
+	"context"
+	"errors"
+	"talana_prueba_tecnica/src/entity/models"
+	"talana_prueba_tecnica/src/entity/requests"
+	"talana_prueba_tecnica/src/infraestructure/repository/game_repository"
+	questionsrepository "talana_prueba_tecnica/src/infraestructure/repository/questions_repository"
+	sessionrepository "talana_prueba_tecnica/src/infraestructure/repository/session_repository"
+	triviarepository "talana_prueba_tecnica/src/infraestructure/repository/trivia_repository"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// The fakes embed the repository interfaces so calls the test does not
+// expect panic.
+
+type fakeGameRepo struct {
+	game_repository.GameRepositoryInterface
+	questions []models.Question
+}
+
+func (r *fakeGameRepo) GetQuestionsForTrivia(_ context.Context, _ uint) ([]models.Question, error) {
+	return r.questions, nil
+}
+
+// fakeUnitOfWork runs fn without a transaction.
+type fakeUnitOfWork struct{}
+
+func (fakeUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type fakeQuestionRepo struct {
+	questionsrepository.QuestionRepositoryInterface
+	questions map[uint]*models.Question
+}
+
+func (r *fakeQuestionRepo) FindByID(_ context.Context, id uint) (*models.Question, error) {
+	question, ok := r.questions[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	found := *question
+	return &found, nil
+}
+
+type fakeTriviaRepo struct {
+	triviarepository.TriviaRepositoryInterface
+	trivia    models.Trivia
+	revisions []models.QuestionRevision
+	attempts  map[uint]int64
+}
+
+func (r *fakeTriviaRepo) IsUserAssigned(_ context.Context, _, userID uint) (bool, error) {
+	for _, user := range r.trivia.Users {
+		if user.ID == userID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *fakeTriviaRepo) CountAttempts(_ context.Context, _, userID uint) (int64, error) {
+	return r.attempts[userID], nil
+}
+
+func (r *fakeTriviaRepo) FindByID(_ context.Context, id uint) (models.Trivia, error) {
+	if id != r.trivia.ID {
+		return models.Trivia{}, gorm.ErrRecordNotFound
+	}
+	return r.trivia, nil
+}
+
+func (r *fakeTriviaRepo) FindQuestionRevision(_ context.Context, questionID uint, revision int) (models.QuestionRevision, error) {
+	for _, candidate := range r.revisions {
+		if candidate.QuestionID == questionID && candidate.Revision == revision {
+			return candidate, nil
+		}
+	}
+	return models.QuestionRevision{}, gorm.ErrRecordNotFound
+}
+
+type fakeSessionRepo struct {
+	sessionrepository.SessionRepositoryInterface
+	session *models.GameSession
+	answers []models.Answer
+	created []*models.GameSession
+}
+
+func (r *fakeSessionRepo) FindActive(_ context.Context, _, _ uint) (*models.GameSession, error) {
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeSessionRepo) CreateSession(_ context.Context, session *models.GameSession) error {
+	session.ID = uint(len(r.created) + 1)
+	r.created = append(r.created, session)
+	return nil
+}
+
+func (r *fakeSessionRepo) FindByID(_ context.Context, id uint) (*models.GameSession, error) {
+	if id != r.session.ID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return r.session, nil
+}
+
+func (r *fakeSessionRepo) MarkServed(_ context.Context, sessionQuestion *models.SessionQuestion) error {
+	now := time.Now()
+	sessionQuestion.ServedAt = &now
+	return nil
+}
+
+func (r *fakeSessionRepo) RecordAnswer(_ context.Context, session *models.GameSession, sessionQuestion *models.SessionQuestion, answer *models.Answer) error {
+	sessionQuestion.AnsweredAt = answer.AnsweredAt
+	if session.Status != models.SessionStatusActive {
+		session.FinishedAt = answer.AnsweredAt
+	}
+	r.answers = append(r.answers, *answer)
+	return nil
+}
+
+func TestAnswerQuestionGradesTheServedRevision(t *testing.T) {
+	question := &models.Question{
+		ID:            10,
+		Question:      "Capital of Chile?",
+		Type:          models.QuestionSingleChoice,
+		Options:       []models.Option{{Text: "Santiago"}, {Text: "Lima"}},
+		CorrectOption: 0,
+		Difficulty:    "facil",
+		Points:        10,
+		Revision:      1,
+		Status:        models.StatusPublished,
+	}
+	triviaRepo := &fakeTriviaRepo{
+		trivia: models.Trivia{
+			ID:        1,
+			Status:    models.StatusPublished,
+			Questions: []models.Question{*question},
+		},
+		revisions: []models.QuestionRevision{*models.NewQuestionRevision(question)},
+	}
+	questionRepo := &fakeQuestionRepo{questions: map[uint]*models.Question{question.ID: question}}
+	sessionRepo := &fakeSessionRepo{session: &models.GameSession{
+		ID:              1,
+		UserID:          7,
+		TriviaID:        1,
+		ParticipationID: 5,
+		Participation:   models.Participation{ID: 5, UserID: 7, TriviaID: 1},
+		Status:          models.SessionStatusActive,
+		StartedAt:       time.Now(),
+		Questions:       []models.SessionQuestion{{ID: 1, SessionID: 1, QuestionID: question.ID, Position: 1}},
+	}}
+	useCase := NewGameUseCase(nil, questionRepo, triviaRepo, sessionRepo, nil)
+	ctx := context.Background()
+
+	served, err := useCase.NextQuestion(ctx, 1, 1)
+	if err != nil {
+		t.Fatalf("NextQuestion: %v", err)
+	}
+	if served.Question.Question != "Capital of Chile?" {
+		t.Fatalf("served question %q", served.Question.Question)
+	}
+
+	// An author edits the question while the player is answering it.
+	question.Question = "Capital of Peru?"
+	question.CorrectOption = 1
+	question.Revision = 2
+	triviaRepo.revisions = append(triviaRepo.revisions, *models.NewQuestionRevision(question))
+
+	result, err := useCase.AnswerQuestion(ctx, 1, 1, &requests.SessionAnswerRequest{QuestionID: question.ID, SelectedOption: 0})
+	if err != nil {
+		t.Fatalf("AnswerQuestion: %v", err)
+	}
+	if !result.IsCorrect || result.Points != 10 {
+		t.Errorf("answer graded correct=%v points=%d, want correct for 10 points against the served revision", result.IsCorrect, result.Points)
+	}
+	if len(sessionRepo.answers) != 1 {
+		t.Fatalf("recorded %d answers, want 1", len(sessionRepo.answers))
+	}
+	if revision := sessionRepo.answers[0].QuestionRevision; revision != 1 {
+		t.Errorf("answer recorded against revision %d, want 1", revision)
+	}
+}
+
+func TestStartSessionsStartsNobodyWhenAPlayerCannotPlay(t *testing.T) {
+	question := models.Question{ID: 10, Status: models.StatusPublished}
+	triviaRepo := &fakeTriviaRepo{
+		trivia: models.Trivia{
+			ID:          1,
+			Status:      models.StatusPublished,
+			MaxAttempts: 1,
+			Questions:   []models.Question{question},
+			Users:       []models.UserModel{{ID: 7}, {ID: 8}},
+		},
+		attempts: map[uint]int64{8: 1},
+	}
+	gameRepo := &fakeGameRepo{questions: []models.Question{question}}
+	sessionRepo := &fakeSessionRepo{}
+	useCase := NewGameUseCase(gameRepo, nil, triviaRepo, sessionRepo, fakeUnitOfWork{})
+	ctx := context.Background()
+
+	if _, err := useCase.StartSessions(ctx, 1, []uint{7, 8}); !errors.Is(err, ErrMaxAttemptsReached) {
+		t.Fatalf("StartSessions error %v, want %v", err, ErrMaxAttemptsReached)
+	}
+	if len(sessionRepo.created) != 0 {
+		t.Fatalf("created %d sessions, want none", len(sessionRepo.created))
+	}
+
+	sessions, err := useCase.StartSessions(ctx, 1, []uint{7})
+	if err != nil {
+		t.Fatalf("StartSessions: %v", err)
+	}
+	if len(sessions) != 1 || sessions[0].UserID != 7 || sessions[0].TotalQuestions != 1 {
+		t.Errorf("started sessions %+v, want one session of 1 question for user ID 7", sessions)
+	}
+}
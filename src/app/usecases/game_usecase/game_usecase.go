@@ -9,31 +9,52 @@ import (
 	"talana_prueba_tecnica/src/entity/responses"
 	"talana_prueba_tecnica/src/infraestructure/repository/game_repository"
 	questionsrepository "talana_prueba_tecnica/src/infraestructure/repository/questions_repository"
+	sessionrepository "talana_prueba_tecnica/src/infraestructure/repository/session_repository"
 	triviarepository "talana_prueba_tecnica/src/infraestructure/repository/trivia_repository"
+	"talana_prueba_tecnica/src/shared"
+	"time"
+
+	"gorm.io/gorm"
 )
 
 type GameUseCase struct {
 	repository   game_repository.GameRepositoryInterface
 	questionRepo questionsrepository.QuestionRepositoryInterface
 	triviaRepo   triviarepository.TriviaRepositoryInterface
+	sessionRepo  sessionrepository.SessionRepositoryInterface
+	unitOfWork   shared.UnitOfWork
 }
 
 func NewGameUseCase(
 	repository game_repository.GameRepositoryInterface,
 	questionRepo questionsrepository.QuestionRepositoryInterface,
 	triviaRepository triviarepository.TriviaRepositoryInterface,
+	sessionRepository sessionrepository.SessionRepositoryInterface,
+	unitOfWork shared.UnitOfWork,
 ) *GameUseCase {
 	return &GameUseCase{
 		repository:   repository,
 		questionRepo: questionRepo,
 		triviaRepo:   triviaRepository,
+		sessionRepo:  sessionRepository,
+		unitOfWork:   unitOfWork,
 	}
 }
 
-func (u *GameUseCase) GetQuestionsForTrivia(ctx context.Context, triviaID uint) ([]responses.QuestionResponse, error) {
+func (u *GameUseCase) GetQuestionsForTrivia(ctx context.Context, triviaID, userID uint) ([]responses.QuestionResponse, error) {
 	log := logrus.WithContext(ctx)
 	log.Infof("Getting questions for trivia ID %d usecase", triviaID)
 
+	trivia, err := u.findPlayableTrivia(ctx, triviaID)
+	if err != nil {
+		return nil, err
+	}
+
+	seed, err := u.attemptSeed(ctx, &trivia, userID)
+	if err != nil {
+		return nil, err
+	}
+
 	questions, err := u.repository.GetQuestionsForTrivia(ctx, triviaID)
 	if err != nil {
 		log.WithError(err).Error("Error getting questions for trivia")
@@ -41,19 +62,21 @@ func (u *GameUseCase) GetQuestionsForTrivia(ctx context.Context, triviaID uint)
 	}
 
 	var response []responses.QuestionResponse
-	for _, question := range questions {
+	for _, question := range arrangeQuestions(&trivia, questions, seed) {
 		var options []responses.OptionResponse
-		for _, option := range question.Options {
+		for i, option := range shownOptions(&trivia, &question, seed) {
 			options = append(options, responses.OptionResponse{
-				ID:     option.ID,
+				Index:  i,
 				Option: option.Text,
 			})
 		}
 		response = append(response, responses.QuestionResponse{
-			ID:         question.ID,
-			Question:   question.Question,
-			Options:    options,
-			Difficulty: question.Difficulty,
+			ID:               question.ID,
+			Question:         question.Question,
+			Type:             question.Type,
+			Options:          options,
+			Difficulty:       question.Difficulty,
+			TimeLimitSeconds: question.TimeLimitSeconds,
 		})
 	}
 
@@ -70,61 +93,204 @@ func (u *GameUseCase) SubmitAnswers(ctx context.Context, triviaID uint, req *req
 		return responses.SubmitAnswersResponse{}, errors.New("no answers provided")
 	}
 
+	trivia, err := u.findPlayableTrivia(ctx, triviaID)
+	if err != nil {
+		return responses.SubmitAnswersResponse{}, err
+	}
+
+	if req.IdempotencyKey != "" {
+		if response, ok, err := u.replaySubmission(ctx, &trivia, req); ok || err != nil {
+			return response, err
+		}
+	}
+
+	if err := u.ensureAssigned(ctx, triviaID, req.UserID); err != nil {
+		return responses.SubmitAnswersResponse{}, err
+	}
+
+	if err := u.ensureAttemptAllowed(ctx, &trivia, req.UserID); err != nil {
+		return responses.SubmitAnswersResponse{}, err
+	}
+
+	strategy, err := NewScoringStrategy(trivia.ScoringStrategy)
+	if err != nil {
+		log.WithError(err).Error("Invalid scoring strategy")
+		return responses.SubmitAnswersResponse{}, err
+	}
+
+	if isTimeLimited(&trivia) {
+		log.Error("Timed trivia submitted without a session")
+		return responses.SubmitAnswersResponse{}, ErrTimedTriviaRequiresSession
+	}
+
+	// Only the questions served in this attempt can be answered, with the
+	// option positions they were shown in.
+	seed, err := u.attemptSeed(ctx, &trivia, req.UserID)
+	if err != nil {
+		return responses.SubmitAnswersResponse{}, err
+	}
+	trivia.Questions = arrangeQuestions(&trivia, trivia.Questions, seed)
+
+	questions := make(map[uint]*models.Question, len(trivia.Questions))
+	for i := range trivia.Questions {
+		questions[trivia.Questions[i].ID] = &trivia.Questions[i]
+	}
+
+	given := make([]requests.AnswerRequest, len(req.Responses))
+	for i, response := range req.Responses {
+		given[i] = unshuffleAnswer(&trivia, questions[response.QuestionID], response, seed)
+	}
+
+	if err := validateAnswers(&trivia, given); err != nil {
+		log.WithError(err).Error("Invalid answers submitted")
+		return responses.SubmitAnswersResponse{}, err
+	}
+
 	var score int
 	var correctAnswers int
 	var answers []*models.Answer
+	var results []responses.AnswerResultResponse
 
-	for _, response := range req.Responses {
-		question, err := u.questionRepo.FindByID(ctx, response.QuestionID)
-		if err != nil {
-			log.WithError(err).Errorf("Question ID %d not found", response.QuestionID)
-			return responses.SubmitAnswersResponse{}, errors.New("invalid question in responses")
-		}
+	for _, response := range given {
+		question := questions[response.QuestionID]
 
-		isCorrect := question.CorrectOption == response.SelectedOption
-		if isCorrect {
+		outcome := newAnswerOutcome(gradeAnswer(question, response))
+		if outcome.IsCorrect {
 			correctAnswers++
-			switch question.Difficulty {
-			case "facil":
-				score += 1
-			case "medio":
-				score += 2
-			case "dificil":
-				score += 3
-			}
 		}
+		points := strategy.Score(question, outcome)
+		score += points
 
-		answers = append(answers, &models.Answer{
-			QuestionID:     response.QuestionID,
-			SelectedOption: response.SelectedOption,
-			IsCorrect:      isCorrect,
-		})
+		answer := newAnswer(question, response)
+		answer.IsCorrect = outcome.IsCorrect
+		answer.Points = points
+		answers = append(answers, answer)
+		results = append(results, toAnswerResult(question, answer))
 	}
 
+	now := time.Now()
 	participation := &models.Participation{
-		UserID:   req.UserID,
-		TriviaID: triviaID,
-		Score:    score,
+		UserID:      req.UserID,
+		TriviaID:    triviaID,
+		Score:       score,
+		ShuffleSeed: seed,
+		StartedAt:   &now,
+		FinishedAt:  &now,
 	}
-	if err := u.triviaRepo.SaveParticipation(ctx, participation); err != nil {
-		log.WithError(err).Error("Error saving participation")
-		return responses.SubmitAnswersResponse{}, err
+	if req.IdempotencyKey != "" {
+		participation.IdempotencyKey = &req.IdempotencyKey
 	}
+	err = u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if err := u.triviaRepo.SaveParticipation(ctx, participation); err != nil {
+			log.WithError(err).Error("Error saving participation")
+			return err
+		}
 
-	for _, answer := range answers {
-		answer.ParticipationID = participation.ID
-		if err := u.repository.SaveAnswer(ctx, answer); err != nil {
-			log.WithError(err).Errorf("Error saving answer for question ID %d", answer.QuestionID)
-			return responses.SubmitAnswersResponse{}, err
+		for _, answer := range answers {
+			answer.ParticipationID = participation.ID
+			if err := u.repository.SaveAnswer(ctx, answer); err != nil {
+				log.WithError(err).Errorf("Error saving answer for question ID %d", answer.QuestionID)
+				return err
+			}
 		}
+		return nil
+	})
+	if err != nil {
+		// A concurrent retry may have stored the participation first.
+		if req.IdempotencyKey != "" {
+			if response, ok, _ := u.replaySubmission(ctx, &trivia, req); ok {
+				return response, nil
+			}
+		}
+		return responses.SubmitAnswersResponse{}, err
 	}
 
 	log.Infof("Answers submitted successfully with score: %d", score)
 	return responses.SubmitAnswersResponse{
-		TriviaID:       triviaID,
-		UserID:         req.UserID,
-		CorrectAnswers: correctAnswers,
-		TotalQuestions: len(req.Responses),
-		Score:          score,
+		TriviaID:        triviaID,
+		UserID:          req.UserID,
+		ScoringStrategy: trivia.ScoringStrategy,
+		CorrectAnswers:  correctAnswers,
+		TotalQuestions:  len(trivia.Questions),
+		Score:           score,
+		Answers:         results,
 	}, nil
 }
+
+// findPlayableTrivia returns the trivia with triviaID keeping only its
+// published questions, or ErrTriviaNotPublished if players cannot be served
+// it.
+func (u *GameUseCase) findPlayableTrivia(ctx context.Context, triviaID uint) (models.Trivia, error) {
+	log := logrus.WithContext(ctx)
+
+	trivia, err := u.triviaRepo.FindByID(ctx, triviaID)
+	if err != nil {
+		log.WithError(err).Error("Trivia not found")
+		return models.Trivia{}, errors.New("trivia not found")
+	}
+	if trivia.Status != models.StatusPublished {
+		log.Errorf("Trivia ID %d is %s", triviaID, trivia.Status)
+		return models.Trivia{}, ErrTriviaNotPublished
+	}
+
+	trivia.Questions = trivia.PublishedQuestions()
+	return trivia, nil
+}
+
+// replaySubmission returns the result of the participation already created
+// with the Idempotency-Key of req, reporting whether there was one.
+func (u *GameUseCase) replaySubmission(ctx context.Context, trivia *models.Trivia, req *requests.SubmitAnswersRequest) (responses.SubmitAnswersResponse, bool, error) {
+	log := logrus.WithContext(ctx)
+
+	participation, err := u.triviaRepo.FindParticipationByIdempotencyKey(ctx, req.UserID, req.IdempotencyKey)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return responses.SubmitAnswersResponse{}, false, nil
+	}
+	if err != nil {
+		return responses.SubmitAnswersResponse{}, false, err
+	}
+
+	if participation.TriviaID != trivia.ID {
+		log.Errorf("Idempotency key reused for trivia ID %d", trivia.ID)
+		return responses.SubmitAnswersResponse{}, false, ErrIdempotencyKeyReused
+	}
+
+	questions := make(map[uint]*models.Question, len(trivia.Questions))
+	for i := range trivia.Questions {
+		questions[trivia.Questions[i].ID] = &trivia.Questions[i]
+	}
+
+	correctAnswers := 0
+	var results []responses.AnswerResultResponse
+	for i, answer := range participation.Answers {
+		if answer.IsCorrect {
+			correctAnswers++
+		}
+		if question, ok := questions[answer.QuestionID]; ok {
+			results = append(results, toAnswerResult(question, &participation.Answers[i]))
+		}
+	}
+
+	log.Infof("Replaying participation ID %d", participation.ID)
+	return responses.SubmitAnswersResponse{
+		TriviaID:        trivia.ID,
+		UserID:          participation.UserID,
+		ScoringStrategy: trivia.ScoringStrategy,
+		CorrectAnswers:  correctAnswers,
+		TotalQuestions:  trivia.ServedQuestionCount(),
+		Score:           participation.Score,
+		Answers:         results,
+	}, true, nil
+}
+
+// toAnswerResult reveals the explanation of question along with the outcome
+// of answer.
+func toAnswerResult(question *models.Question, answer *models.Answer) responses.AnswerResultResponse {
+	return responses.AnswerResultResponse{
+		QuestionID:   question.ID,
+		IsCorrect:    answer.IsCorrect,
+		Points:       answer.Points,
+		Explanation:  question.Explanation,
+		ReferenceURL: question.ReferenceURL,
+	}
+}
@@ -7,6 +7,11 @@ import (
 )
 
 type GameUseCaseInterface interface {
-	GetQuestionsForTrivia(ctx context.Context, triviaID uint) ([]responses.QuestionResponse, error)
+	GetQuestionsForTrivia(ctx context.Context, triviaID, userID uint) ([]responses.QuestionResponse, error)
 	SubmitAnswers(ctx context.Context, triviaID uint, req *requests.SubmitAnswersRequest) (responses.SubmitAnswersResponse, error)
+	StartSession(ctx context.Context, triviaID uint, req *requests.StartSessionRequest) (responses.GameSessionResponse, error)
+	StartSessions(ctx context.Context, triviaID uint, userIDs []uint) ([]responses.GameSessionResponse, error)
+	NextQuestion(ctx context.Context, triviaID, sessionID uint) (responses.SessionQuestionResponse, error)
+	AnswerQuestion(ctx context.Context, triviaID, sessionID uint, req *requests.SessionAnswerRequest) (responses.SessionAnswerResponse, error)
+	SkipQuestion(ctx context.Context, triviaID, sessionID uint) error
 }
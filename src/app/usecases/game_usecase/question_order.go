@@ -0,0 +1,124 @@
+package game_usecase
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+	"sort"
+	"talana_prueba_tecnica/src/entity/models"
+	"talana_prueba_tecnica/src/entity/requests"
+
+	"github.com/sirupsen/logrus"
+)
+
+// attemptSeed returns the shuffle seed of the next attempt of userID at the
+// trivia. It only changes once an attempt is recorded, so reloading the
+// questions shows them in the same order.
+func (u *GameUseCase) attemptSeed(ctx context.Context, trivia *models.Trivia, userID uint) (int64, error) {
+	if !trivia.Randomized() {
+		return 0, nil
+	}
+
+	attempts, err := u.triviaRepo.CountAttempts(ctx, trivia.ID, userID)
+	if err != nil {
+		logrus.WithContext(ctx).WithError(err).Error("Error counting attempts")
+		return 0, err
+	}
+
+	hash := fnv.New64a()
+	fmt.Fprintf(hash, "%d:%d:%d", trivia.ID, userID, attempts+1)
+	return int64(hash.Sum64() >> 1), nil
+}
+
+// arrangeQuestions returns the questions served with seed: QuestionPoolSize
+// of them drawn at random when set, shuffled when ShuffleQuestions is on and
+// by ID otherwise.
+func arrangeQuestions(trivia *models.Trivia, questions []models.Question, seed int64) []models.Question {
+	arranged := make([]models.Question, len(questions))
+	copy(arranged, questions)
+	sort.Slice(arranged, func(i, j int) bool { return arranged[i].ID < arranged[j].ID })
+
+	poolSize := trivia.QuestionPoolSize
+	if poolSize <= 0 || poolSize > len(arranged) {
+		poolSize = len(arranged)
+	}
+	if !trivia.ShuffleQuestions && poolSize == len(arranged) {
+		return arranged
+	}
+
+	random := rand.New(rand.NewPCG(uint64(seed), 0))
+	random.Shuffle(len(arranged), func(i, j int) {
+		arranged[i], arranged[j] = arranged[j], arranged[i]
+	})
+	arranged = arranged[:poolSize]
+
+	if !trivia.ShuffleQuestions {
+		sort.Slice(arranged, func(i, j int) bool { return arranged[i].ID < arranged[j].ID })
+	}
+	return arranged
+}
+
+// optionOrder returns the original index of the option shown at each
+// position of question, or nil when its options keep their order. True/false
+// options are never shuffled.
+func optionOrder(trivia *models.Trivia, question *models.Question, seed int64) []int {
+	if !trivia.ShuffleOptions || !question.HasChoices() || question.Type == models.QuestionTrueFalse {
+		return nil
+	}
+
+	order := make([]int, len(question.Options))
+	for i := range order {
+		order[i] = i
+	}
+	random := rand.New(rand.NewPCG(uint64(seed), uint64(question.ID)))
+	random.Shuffle(len(order), func(i, j int) {
+		order[i], order[j] = order[j], order[i]
+	})
+	return order
+}
+
+// shownOptions returns the options of question in the order the player
+// sees them.
+func shownOptions(trivia *models.Trivia, question *models.Question, seed int64) []models.Option {
+	order := optionOrder(trivia, question, seed)
+	if order == nil {
+		return question.PlayerOptions()
+	}
+
+	options := make([]models.Option, len(order))
+	for position, index := range order {
+		options[position] = question.Options[index]
+	}
+	return options
+}
+
+// unshuffleAnswer maps the option positions of answer, as shown to the
+// player, back to the indexes of question.Options. Positions out of range
+// are kept so validation reports them.
+func unshuffleAnswer(trivia *models.Trivia, question *models.Question, answer requests.AnswerRequest, seed int64) requests.AnswerRequest {
+	if question == nil {
+		return answer
+	}
+	order := optionOrder(trivia, question, seed)
+	if order == nil {
+		return answer
+	}
+
+	original := func(position uint) uint {
+		if int(position) >= len(order) {
+			return position
+		}
+		return uint(order[position])
+	}
+
+	answer.SelectedOption = original(answer.SelectedOption)
+	if answer.SelectedOptions != nil {
+		selected := make([]uint, len(answer.SelectedOptions))
+		for i, position := range answer.SelectedOptions {
+			selected[i] = original(position)
+		}
+		answer.SelectedOptions = selected
+	}
+	return answer
+}
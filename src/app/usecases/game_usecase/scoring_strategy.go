@@ -0,0 +1,111 @@
+package game_usecase
+
+import (
+	"fmt"
+	"math"
+	"talana_prueba_tecnica/src/entity/models"
+	"time"
+)
+
+// ScoringStrategy decides how many points an answer is worth. Strategies are
+// selected per trivia through models.Trivia.ScoringStrategy.
+type ScoringStrategy interface {
+	Score(question *models.Question, outcome AnswerOutcome) int
+}
+
+// AnswerOutcome describes how a question was answered. Credit is the share of
+// the question earned, 1 for correct answers and between 0 and 1 for partially
+// correct multi-select answers. Elapsed is zero when the answer was not timed
+// (e.g. one-shot submissions) and TimeLimit is zero when the question has no
+// time limit.
+type AnswerOutcome struct {
+	IsCorrect bool
+	Credit    float64
+	Elapsed   time.Duration
+	TimeLimit time.Duration
+}
+
+// newAnswerOutcome grades credit, treating only full credit as correct.
+func newAnswerOutcome(credit float64) AnswerOutcome {
+	return AnswerOutcome{IsCorrect: credit >= 1, Credit: credit}
+}
+
+// earned returns the share of points earned by the outcome.
+func (o AnswerOutcome) earned(points int) int {
+	if o.IsCorrect {
+		return points
+	}
+	return int(math.Round(float64(points) * o.Credit))
+}
+
+// QuestionPointsStrategy awards the points configured on the question.
+type QuestionPointsStrategy struct{}
+
+func (QuestionPointsStrategy) Score(question *models.Question, outcome AnswerOutcome) int {
+	return outcome.earned(question.Points)
+}
+
+// DifficultyWeightsStrategy awards a fixed weight per difficulty level,
+// ignoring the points configured on the question.
+type DifficultyWeightsStrategy struct {
+	Weights map[string]int
+}
+
+func (s DifficultyWeightsStrategy) Score(question *models.Question, outcome AnswerOutcome) int {
+	return outcome.earned(s.Weights[question.Difficulty])
+}
+
+// NegativeMarkingStrategy awards the question points for a correct answer and
+// subtracts a fraction of them for a wrong one. Partially correct answers
+// earn their share without penalty.
+type NegativeMarkingStrategy struct {
+	Penalty float64
+}
+
+func (s NegativeMarkingStrategy) Score(question *models.Question, outcome AnswerOutcome) int {
+	if outcome.IsCorrect || outcome.Credit > 0 {
+		return outcome.earned(question.Points)
+	}
+	return -int(math.Round(float64(question.Points) * s.Penalty))
+}
+
+// TimeBonusStrategy awards the question points plus a bonus that decreases
+// linearly with the time it took to answer, reaching zero at the question time
+// limit, or at Window when the question is not time limited. Partially
+// correct answers earn their share without bonus.
+type TimeBonusStrategy struct {
+	Window time.Duration
+}
+
+func (s TimeBonusStrategy) Score(question *models.Question, outcome AnswerOutcome) int {
+	if !outcome.IsCorrect {
+		return outcome.earned(question.Points)
+	}
+
+	window := s.Window
+	if outcome.TimeLimit > 0 {
+		window = outcome.TimeLimit
+	}
+	if outcome.Elapsed <= 0 || outcome.Elapsed >= window {
+		return question.Points
+	}
+
+	remaining := 1 - float64(outcome.Elapsed)/float64(window)
+	return question.Points + int(math.Round(float64(question.Points)*remaining))
+}
+
+// NewScoringStrategy returns the strategy registered under name. An empty
+// name falls back to models.DefaultScoringStrategy.
+func NewScoringStrategy(name string) (ScoringStrategy, error) {
+	switch name {
+	case "", models.ScoringQuestionPoints:
+		return QuestionPointsStrategy{}, nil
+	case models.ScoringDifficulty:
+		return DifficultyWeightsStrategy{Weights: map[string]int{"facil": 1, "medio": 2, "dificil": 3}}, nil
+	case models.ScoringNegativeMarking:
+		return NegativeMarkingStrategy{Penalty: 0.5}, nil
+	case models.ScoringTimeBonus:
+		return TimeBonusStrategy{Window: 30 * time.Second}, nil
+	}
+	return nil, fmt.Errorf("unknown scoring strategy %q", name)
+}
@@ -0,0 +1,60 @@
+package game_usecase
+
+import (
+	"talana_prueba_tecnica/src/entity/models"
+	"testing"
+	"time"
+)
+
+func TestScoringStrategies(t *testing.T) {
+	question := &models.Question{Points: 10, Difficulty: "dificil"}
+	correct := newAnswerOutcome(1)
+	wrong := newAnswerOutcome(0)
+	half := newAnswerOutcome(0.5)
+	timed := func(elapsed, limit time.Duration) AnswerOutcome {
+		outcome := newAnswerOutcome(1)
+		outcome.Elapsed, outcome.TimeLimit = elapsed, limit
+		return outcome
+	}
+
+	tests := []struct {
+		name     string
+		strategy string
+		outcome  AnswerOutcome
+		want     int
+	}{
+		{"question points for a correct answer", models.ScoringQuestionPoints, correct, 10},
+		{"question points for a wrong answer", models.ScoringQuestionPoints, wrong, 0},
+		{"question points for a partial answer", models.ScoringQuestionPoints, half, 5},
+		{"default strategy", "", correct, 10},
+		{"difficulty weight for a correct answer", models.ScoringDifficulty, correct, 3},
+		{"difficulty weight for a partial answer", models.ScoringDifficulty, half, 2},
+		{"negative marking for a correct answer", models.ScoringNegativeMarking, correct, 10},
+		{"negative marking for a wrong answer", models.ScoringNegativeMarking, wrong, -5},
+		{"negative marking for a partial answer", models.ScoringNegativeMarking, half, 5},
+		{"time bonus for an untimed answer", models.ScoringTimeBonus, correct, 10},
+		{"time bonus for an instant answer", models.ScoringTimeBonus, timed(time.Millisecond, 0), 20},
+		{"time bonus within the default window", models.ScoringTimeBonus, timed(15*time.Second, 0), 15},
+		{"time bonus within the question time limit", models.ScoringTimeBonus, timed(6*time.Second, 10*time.Second), 14},
+		{"time bonus after the time limit", models.ScoringTimeBonus, timed(12*time.Second, 10*time.Second), 10},
+		{"time bonus for a wrong answer", models.ScoringTimeBonus, wrong, 0},
+		{"time bonus for a partial answer", models.ScoringTimeBonus, half, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strategy, err := NewScoringStrategy(tt.strategy)
+			if err != nil {
+				t.Fatalf("NewScoringStrategy(%q): %v", tt.strategy, err)
+			}
+			if got := strategy.Score(question, tt.outcome); got != tt.want {
+				t.Errorf("Score = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewScoringStrategyRejectsUnknownNames(t *testing.T) {
+	if _, err := NewScoringStrategy("fastest_finger"); err == nil {
+		t.Fatal("NewScoringStrategy accepted an unknown strategy")
+	}
+}
@@ -0,0 +1,166 @@
+package leaderboardusecase
+
+import (
+	"context"
+	"errors"
+	"talana_prueba_tecnica/src/entity/models"
+	"talana_prueba_tecnica/src/entity/requests"
+	"talana_prueba_tecnica/src/entity/responses"
+	leaderboardrepository "talana_prueba_tecnica/src/infraestructure/repository/leaderboard_repository"
+	"talana_prueba_tecnica/src/shared"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrSeasonNotFound    = errors.New("season not found")
+	ErrInvalidSeason     = errors.New("season requires a name, a slug and ends_at after starts_at")
+	ErrInvalidDifficulty = errors.New("difficulty must be one of facil, medio, dificil")
+	ErrInvalidDateRange  = errors.New("from must be before to")
+)
+
+type LeaderboardUseCase struct {
+	repository leaderboardrepository.LeaderboardRepositoryInterface
+}
+
+func NewLeaderboardUseCase(repository leaderboardrepository.LeaderboardRepositoryInterface) *LeaderboardUseCase {
+	return &LeaderboardUseCase{
+		repository: repository,
+	}
+}
+
+func (u *LeaderboardUseCase) GetGlobalLeaderboard(ctx context.Context, query *requests.LeaderboardQuery) (responses.LeaderboardResponse, error) {
+	log := logrus.WithContext(ctx)
+	log.Info("Get global leaderboard usecase")
+
+	if query.From != nil && query.To != nil && !query.From.Before(*query.To) {
+		log.Error("Invalid date range")
+		return responses.LeaderboardResponse{}, ErrInvalidDateRange
+	}
+
+	return u.leaderboard(ctx, query, nil)
+}
+
+func (u *LeaderboardUseCase) GetSeasonLeaderboard(ctx context.Context, slug string, query *requests.LeaderboardQuery) (responses.LeaderboardResponse, error) {
+	log := logrus.WithContext(ctx)
+	log.Infof("Get season %s leaderboard usecase", slug)
+
+	season, err := u.repository.FindSeasonBySlug(ctx, slug)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return responses.LeaderboardResponse{}, ErrSeasonNotFound
+		}
+		log.WithError(err).Error("Error finding season")
+		return responses.LeaderboardResponse{}, err
+	}
+
+	query.From = &season.StartsAt
+	query.To = &season.EndsAt
+	return u.leaderboard(ctx, query, season)
+}
+
+func (u *LeaderboardUseCase) CreateSeason(ctx context.Context, req *requests.CreateSeasonRequest) (responses.SeasonResponse, error) {
+	log := logrus.WithContext(ctx)
+	log.Info("Create season usecase")
+
+	if req.Name == "" || req.Slug == "" || !req.StartsAt.Before(req.EndsAt) {
+		log.Error("Invalid season")
+		return responses.SeasonResponse{}, ErrInvalidSeason
+	}
+
+	season := &models.Season{
+		Name:     req.Name,
+		Slug:     req.Slug,
+		StartsAt: req.StartsAt,
+		EndsAt:   req.EndsAt,
+	}
+	if err := u.repository.CreateSeason(ctx, season); err != nil {
+		log.WithError(err).Error("Error creating season in repository")
+		return responses.SeasonResponse{}, err
+	}
+
+	log.Info("Season created successfully")
+	return toSeasonResponse(season), nil
+}
+
+func (u *LeaderboardUseCase) FindAllSeasons(ctx context.Context) ([]responses.SeasonResponse, error) {
+	log := logrus.WithContext(ctx)
+	log.Info("Find all seasons usecase")
+
+	seasons, err := u.repository.FindAllSeasons(ctx)
+	if err != nil {
+		log.WithError(err).Error("Error finding seasons in repository")
+		return nil, err
+	}
+
+	var seasonsList []responses.SeasonResponse
+	for _, season := range seasons {
+		seasonsList = append(seasonsList, toSeasonResponse(&season))
+	}
+
+	log.Info("Seasons found")
+	return seasonsList, nil
+}
+
+func (u *LeaderboardUseCase) leaderboard(ctx context.Context, query *requests.LeaderboardQuery, season *models.Season) (responses.LeaderboardResponse, error) {
+	log := logrus.WithContext(ctx)
+
+	switch query.Difficulty {
+	case "", "facil", "medio", "dificil":
+	default:
+		log.Errorf("Invalid difficulty %s", query.Difficulty)
+		return responses.LeaderboardResponse{}, ErrInvalidDifficulty
+	}
+
+	page, pageSize, offset := shared.NormalizePage(query.Page, query.PageSize)
+	filter := leaderboardrepository.LeaderboardFilter{
+		From:       query.From,
+		To:         query.To,
+		Department: query.Department,
+		Difficulty: query.Difficulty,
+	}
+
+	rankings, total, err := u.repository.GetLeaderboard(ctx, filter, pageSize, offset)
+	if err != nil {
+		log.WithError(err).Error("Error getting leaderboard in repository")
+		return responses.LeaderboardResponse{}, err
+	}
+
+	response := responses.LeaderboardResponse{
+		From:       query.From,
+		To:         query.To,
+		Department: query.Department,
+		Difficulty: query.Difficulty,
+		Page:       page,
+		PageSize:   pageSize,
+		Total:      total,
+		Entries:    []responses.LeaderboardEntryResponse{},
+	}
+	if season != nil {
+		seasonResponse := toSeasonResponse(season)
+		response.Season = &seasonResponse
+	}
+	for _, ranking := range rankings {
+		response.Entries = append(response.Entries, responses.LeaderboardEntryResponse{
+			Rank:           ranking.Rank,
+			UserID:         ranking.UserID,
+			Name:           ranking.Name,
+			Score:          ranking.TotalScore,
+			CorrectAnswers: ranking.CorrectAnswers,
+		})
+	}
+
+	log.Info("Leaderboard retrieved successfully")
+	return response, nil
+}
+
+func toSeasonResponse(season *models.Season) responses.SeasonResponse {
+	return responses.SeasonResponse{
+		ID:       season.ID,
+		Name:     season.Name,
+		Slug:     season.Slug,
+		StartsAt: season.StartsAt,
+		EndsAt:   season.EndsAt,
+	}
+}
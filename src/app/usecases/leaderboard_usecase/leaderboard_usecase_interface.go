@@ -0,0 +1,14 @@
+package leaderboardusecase
+
+import (
+	"context"
+	"talana_prueba_tecnica/src/entity/requests"
+	"talana_prueba_tecnica/src/entity/responses"
+)
+
+type LeaderboardUseCaseInterface interface {
+	GetGlobalLeaderboard(ctx context.Context, query *requests.LeaderboardQuery) (responses.LeaderboardResponse, error)
+	GetSeasonLeaderboard(ctx context.Context, slug string, query *requests.LeaderboardQuery) (responses.LeaderboardResponse, error)
+	CreateSeason(ctx context.Context, req *requests.CreateSeasonRequest) (responses.SeasonResponse, error)
+	FindAllSeasons(ctx context.Context) ([]responses.SeasonResponse, error)
+}
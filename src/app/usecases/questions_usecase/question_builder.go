@@ -0,0 +1,162 @@
+package questionsusecase
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"talana_prueba_tecnica/src/entity/models"
+	"talana_prueba_tecnica/src/entity/requests"
+)
+
+var ErrInvalidQuestion = errors.New("invalid question")
+
+const maxQuestionLength = 255
+
+// trueFalseOptions are used when a true/false question is sent without options.
+var trueFalseOptions = []string{"True", "False"}
+
+func invalidQuestion(message string) error {
+	return fmt.Errorf("%w: %s", ErrInvalidQuestion, message)
+}
+
+// buildQuestion validates req against its question type and maps it to a
+// question with its options.
+func buildQuestion(req *requests.CreateQuestionRequest) (*models.Question, error) {
+	questionType := req.Type
+	if questionType == "" {
+		questionType = models.DefaultQuestionType
+	}
+	if !models.IsValidQuestionType(questionType) {
+		return nil, invalidQuestion(fmt.Sprintf("unknown question type %q", req.Type))
+	}
+
+	if text := strings.TrimSpace(req.Question); text == "" || len(text) > maxQuestionLength {
+		return nil, invalidQuestion(fmt.Sprintf("question text is required and must be at most %d characters", maxQuestionLength))
+	}
+
+	if !isDifficulty(req.Difficulty) {
+		return nil, invalidQuestion(ErrInvalidDifficulty.Error())
+	}
+
+	if req.TimeLimitSeconds < 0 {
+		return nil, invalidQuestion("time limit cannot be negative")
+	}
+
+	referenceURL := strings.TrimSpace(req.ReferenceURL)
+	if referenceURL != "" && !isWebURL(referenceURL) {
+		return nil, invalidQuestion("reference url must be an absolute http or https URL")
+	}
+
+	question := &models.Question{
+		Status:           models.StatusDraft,
+		Question:         req.Question,
+		Type:             questionType,
+		Difficulty:       req.Difficulty,
+		TimeLimitSeconds: req.TimeLimitSeconds,
+		Points:           req.Points,
+		Explanation:      strings.TrimSpace(req.Explanation),
+		ReferenceURL:     referenceURL,
+	}
+
+	var err error
+	switch questionType {
+	case models.QuestionSingleChoice, models.QuestionTrueFalse:
+		err = buildChoiceQuestion(question, req)
+	case models.QuestionMultiSelect:
+		err = buildMultiSelectQuestion(question, req)
+	case models.QuestionNumeric:
+		if req.NumericAnswer == nil {
+			return nil, invalidQuestion("numeric questions require a numeric answer")
+		}
+		if req.Tolerance < 0 {
+			return nil, invalidQuestion("tolerance cannot be negative")
+		}
+		question.NumericAnswer = req.NumericAnswer
+		question.Tolerance = req.Tolerance
+	case models.QuestionFreeText:
+		if len(req.AcceptedAnswers) == 0 {
+			return nil, invalidQuestion("free text questions require at least one accepted answer")
+		}
+		question.Options, err = newOptions(req.AcceptedAnswers)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return question, nil
+}
+
+func buildChoiceQuestion(question *models.Question, req *requests.CreateQuestionRequest) error {
+	texts := req.Options
+	if question.Type == models.QuestionTrueFalse {
+		if len(texts) == 0 {
+			texts = trueFalseOptions
+		}
+		if len(texts) != 2 {
+			return invalidQuestion("true/false questions have exactly two options")
+		}
+	}
+	if len(texts) < 2 {
+		return invalidQuestion("at least two options are required")
+	}
+
+	if req.CorrectOption >= len(texts) || req.CorrectOption < 0 {
+		return invalidQuestion("invalid correct option index")
+	}
+
+	options, err := newOptions(texts)
+	if err != nil {
+		return err
+	}
+	question.Options = options
+	question.CorrectOption = uint(req.CorrectOption)
+	return nil
+}
+
+func buildMultiSelectQuestion(question *models.Question, req *requests.CreateQuestionRequest) error {
+	if len(req.Options) < 2 {
+		return invalidQuestion("at least two options are required")
+	}
+	if len(req.CorrectOptions) == 0 {
+		return invalidQuestion("multi-select questions require at least one correct option")
+	}
+
+	options, err := newOptions(req.Options)
+	if err != nil {
+		return err
+	}
+
+	for _, index := range req.CorrectOptions {
+		if index < 0 || index >= len(options) {
+			return invalidQuestion("invalid correct option index")
+		}
+		if options[index].IsCorrect {
+			return invalidQuestion("correct option listed more than once")
+		}
+		options[index].IsCorrect = true
+	}
+
+	question.Options = options
+	question.PartialCredit = req.PartialCredit
+	return nil
+}
+
+func newOptions(texts []string) ([]models.Option, error) {
+	options := make([]models.Option, 0, len(texts))
+	for _, text := range texts {
+		if strings.TrimSpace(text) == "" {
+			return nil, invalidQuestion("all options must have text")
+		}
+		options = append(options, models.Option{Text: text})
+	}
+	return options, nil
+}
+
+func isWebURL(raw string) bool {
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Host == "" {
+		return false
+	}
+	return parsed.Scheme == "http" || parsed.Scheme == "https"
+}
@@ -0,0 +1,308 @@
+package questionsusecase
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"talana_prueba_tecnica/src/entity/models"
+	"talana_prueba_tecnica/src/entity/requests"
+	"talana_prueba_tecnica/src/entity/responses"
+	categoryrepository "talana_prueba_tecnica/src/infraestructure/repository/category_repository"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	categoryPathSeparator = "/"
+	maxCategoryNameLength = 100
+)
+
+// csvExportColumns are the columns written by WriteBundleCSV, all of them
+// read back by the CSV import.
+var csvExportColumns = []string{
+	"question", "type", "difficulty", "points", "options", "correct_option", "correct_options",
+	"partial_credit", "numeric_answer", "tolerance", "accepted_answers", "time_limit_seconds",
+	"explanation", "reference_url", "categories", "tags",
+}
+
+// ExportQuestions returns the questions matching query as a bundle.
+func (u *QuestionsUseCase) ExportQuestions(ctx context.Context, query requests.QuestionQuery) (responses.ContentBundle, error) {
+	log := logrus.WithContext(ctx)
+	log.Info("Exporting questions usecase")
+
+	filter, err := toQuestionFilter(query)
+	if err != nil {
+		log.WithError(err).Error("Invalid question filter")
+		return responses.ContentBundle{}, err
+	}
+
+	questions, err := u.repository.FindAll(ctx, filter)
+	if err != nil {
+		log.WithError(err).Error("Error finding questions")
+		return responses.ContentBundle{}, err
+	}
+
+	bundled, err := u.toBundleQuestions(ctx, questions)
+	if err != nil {
+		return responses.ContentBundle{}, err
+	}
+
+	log.Infof("Exported %d questions", len(bundled))
+	return responses.ContentBundle{
+		Version:    responses.BundleVersion,
+		ExportedAt: time.Now().UTC(),
+		Questions:  bundled,
+	}, nil
+}
+
+// BundleQuestions returns the questions with the given IDs in bundle form.
+func (u *QuestionsUseCase) BundleQuestions(ctx context.Context, ids []uint) ([]responses.BundleQuestion, error) {
+	log := logrus.WithContext(ctx)
+
+	var questions []models.Question
+	for _, id := range ids {
+		question, err := u.repository.FindByID(ctx, id)
+		if err != nil {
+			log.WithError(err).Errorf("Error finding question ID %d", id)
+			return nil, err
+		}
+		questions = append(questions, *question)
+	}
+	return u.toBundleQuestions(ctx, questions)
+}
+
+// ImportBundleQuestions creates the questions of a bundle atomically and
+// returns the ID of the question created for every bundle ref.
+func (u *QuestionsUseCase) ImportBundleQuestions(ctx context.Context, questions []responses.BundleQuestion) (map[uint]uint, error) {
+	log := logrus.WithContext(ctx)
+	log.Infof("Importing %d bundle questions", len(questions))
+
+	ids := map[uint]uint{}
+	err := u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		categories := newCategoryResolver(u.categoryRepo)
+		for _, item := range questions {
+			if _, ok := ids[item.Ref]; ok {
+				return invalidQuestion(fmt.Sprintf("question ref %d is repeated", item.Ref))
+			}
+
+			row := fromBundleQuestion(item)
+			id, err := u.importQuestion(ctx, &row, categories)
+			if err != nil {
+				return fmt.Errorf("question ref %d: %w", item.Ref, err)
+			}
+			ids[item.Ref] = id
+		}
+		return nil
+	})
+	if err != nil {
+		log.WithError(err).Error("Error importing bundle questions")
+		return nil, err
+	}
+	return ids, nil
+}
+
+// WriteBundleCSV writes questions in the CSV format read by the question
+// import. CSV files carry no trivia settings.
+func WriteBundleCSV(w io.Writer, questions []responses.BundleQuestion) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvExportColumns); err != nil {
+		return err
+	}
+
+	for _, question := range questions {
+		var numericAnswer string
+		if question.NumericAnswer != nil {
+			numericAnswer = strconv.FormatFloat(*question.NumericAnswer, 'f', -1, 64)
+		}
+		var correctOptions []string
+		for _, index := range question.CorrectOptions {
+			correctOptions = append(correctOptions, strconv.Itoa(index))
+		}
+
+		err := writer.Write([]string{
+			question.Question,
+			question.Type,
+			question.Difficulty,
+			strconv.Itoa(question.Points),
+			strings.Join(question.Options, importListSeparator),
+			strconv.Itoa(question.CorrectOption),
+			strings.Join(correctOptions, importListSeparator),
+			strconv.FormatBool(question.PartialCredit),
+			numericAnswer,
+			strconv.FormatFloat(question.Tolerance, 'f', -1, 64),
+			strings.Join(question.AcceptedAnswers, importListSeparator),
+			strconv.Itoa(question.TimeLimitSeconds),
+			question.Explanation,
+			question.ReferenceURL,
+			strings.Join(question.Categories, importListSeparator),
+			strings.Join(question.Tags, importListSeparator),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func parseBundleImport(data []byte) ([]importRow, error) {
+	var bundle responses.ContentBundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return nil, fmt.Errorf("%w: malformed bundle: %v", ErrInvalidImport, err)
+	}
+	if bundle.Version != responses.BundleVersion {
+		return nil, fmt.Errorf("%w: unsupported bundle version %d", ErrInvalidImport, bundle.Version)
+	}
+
+	rows := make([]importRow, len(bundle.Questions))
+	for i, item := range bundle.Questions {
+		rows[i] = fromBundleQuestion(item)
+		rows[i].line = i + 1
+	}
+	return rows, nil
+}
+
+func fromBundleQuestion(item responses.BundleQuestion) importRow {
+	return importRow{
+		req: requests.CreateQuestionRequest{
+			Question:         item.Question,
+			Type:             item.Type,
+			Difficulty:       item.Difficulty,
+			Points:           item.Points,
+			Options:          item.Options,
+			CorrectOption:    item.CorrectOption,
+			CorrectOptions:   item.CorrectOptions,
+			PartialCredit:    item.PartialCredit,
+			NumericAnswer:    item.NumericAnswer,
+			Tolerance:        item.Tolerance,
+			AcceptedAnswers:  item.AcceptedAnswers,
+			TimeLimitSeconds: item.TimeLimitSeconds,
+			Explanation:      item.Explanation,
+			ReferenceURL:     item.ReferenceURL,
+			Tags:             item.Tags,
+		},
+		categoryPaths: item.Categories,
+	}
+}
+
+func (u *QuestionsUseCase) toBundleQuestions(ctx context.Context, questions []models.Question) ([]responses.BundleQuestion, error) {
+	categories, err := u.categoryRepo.FindAll(ctx)
+	if err != nil {
+		logrus.WithContext(ctx).WithError(err).Error("Error finding categories")
+		return nil, err
+	}
+	paths := categoryPaths(categories)
+
+	bundled := []responses.BundleQuestion{}
+	for _, question := range questions {
+		item := responses.BundleQuestion{
+			Ref:              question.ID,
+			Question:         question.Question,
+			Type:             question.Type,
+			Difficulty:       question.Difficulty,
+			Points:           question.Points,
+			CorrectOption:    int(question.CorrectOption),
+			PartialCredit:    question.PartialCredit,
+			NumericAnswer:    question.NumericAnswer,
+			Tolerance:        question.Tolerance,
+			TimeLimitSeconds: question.TimeLimitSeconds,
+			Explanation:      question.Explanation,
+			ReferenceURL:     question.ReferenceURL,
+			Tags:             tagNames(question.Tags),
+		}
+		for i, option := range question.Options {
+			if question.Type == models.QuestionFreeText {
+				item.AcceptedAnswers = append(item.AcceptedAnswers, option.Text)
+				continue
+			}
+			item.Options = append(item.Options, option.Text)
+			if question.Type == models.QuestionMultiSelect && option.IsCorrect {
+				item.CorrectOptions = append(item.CorrectOptions, i)
+			}
+		}
+		for _, category := range question.Categories {
+			item.Categories = append(item.Categories, paths[category.ID])
+		}
+		sort.Strings(item.Categories)
+		bundled = append(bundled, item)
+	}
+	return bundled, nil
+}
+
+// categoryPaths returns the path of every category, joining the names of
+// its ancestors with categoryPathSeparator.
+func categoryPaths(categories []models.Category) map[uint]string {
+	byID := make(map[uint]models.Category, len(categories))
+	for _, category := range categories {
+		byID[category.ID] = category
+	}
+
+	paths := make(map[uint]string, len(categories))
+	for _, category := range categories {
+		path := category.Name
+		for parentID := category.ParentID; parentID != nil; {
+			parent, ok := byID[*parentID]
+			if !ok {
+				break
+			}
+			path = parent.Name + categoryPathSeparator + path
+			parentID = parent.ParentID
+		}
+		paths[category.ID] = path
+	}
+	return paths
+}
+
+// categoryResolver finds categories by path during an import, creating the
+// missing ones. Existing categories are loaded once per import.
+type categoryResolver struct {
+	repository categoryrepository.CategoryRepositoryInterface
+	byPath     map[string]uint
+}
+
+func newCategoryResolver(repository categoryrepository.CategoryRepositoryInterface) *categoryResolver {
+	return &categoryResolver{repository: repository}
+}
+
+func (r *categoryResolver) resolve(ctx context.Context, path string) (uint, error) {
+	if r.byPath == nil {
+		categories, err := r.repository.FindAll(ctx)
+		if err != nil {
+			return 0, err
+		}
+		r.byPath = map[string]uint{}
+		for id, path := range categoryPaths(categories) {
+			r.byPath[path] = id
+		}
+	}
+
+	var parentID *uint
+	var current []string
+	for _, name := range strings.Split(path, categoryPathSeparator) {
+		name = strings.TrimSpace(name)
+		if name == "" || len(name) > maxCategoryNameLength {
+			return 0, invalidQuestion(fmt.Sprintf("invalid category path %q", path))
+		}
+		current = append(current, name)
+
+		key := strings.Join(current, categoryPathSeparator)
+		id, ok := r.byPath[key]
+		if !ok {
+			category := &models.Category{Name: name, ParentID: parentID}
+			if err := r.repository.CreateCategory(ctx, category); err != nil {
+				return 0, err
+			}
+			id = category.ID
+			r.byPath[key] = id
+		}
+		parentID = &id
+	}
+	return *parentID, nil
+}
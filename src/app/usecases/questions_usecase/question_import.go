@@ -0,0 +1,460 @@
+package questionsusecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"talana_prueba_tecnica/src/entity/models"
+	"talana_prueba_tecnica/src/entity/requests"
+	"talana_prueba_tecnica/src/entity/responses"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrInvalidImport  = errors.New("invalid import file")
+	ErrImportRejected = errors.New("import rejected, no question was saved")
+
+	// errImportRolledBack undoes the import transaction on dry runs and
+	// when some row is invalid.
+	errImportRolledBack = errors.New("import rolled back")
+)
+
+const (
+	importFormatCSV        = "csv"
+	importFormatJSON       = "json"
+	importFormatOpenTrivia = "opentdb"
+	importFormatBundle     = "bundle"
+
+	maxImportRows = 1000
+
+	// importListSeparator separates the values of list columns in CSV files,
+	// such as options or tags.
+	importListSeparator = "|"
+)
+
+// importRow is a question read from an import file; err is set when the row
+// could not be read. categoryPaths are resolved to categories on import, see
+// categoryResolver.
+type importRow struct {
+	line          int
+	req           requests.CreateQuestionRequest
+	categoryPaths []string
+	err           error
+}
+
+// ImportQuestions creates every question of the file in req in a single
+// transaction. Nothing is saved when any row is invalid or on dry runs, but
+// the report still has the outcome of every row.
+func (u *QuestionsUseCase) ImportQuestions(ctx context.Context, req *requests.ImportQuestionsRequest) (responses.QuestionImportResponse, error) {
+	log := logrus.WithContext(ctx)
+	log.Info("Importing questions usecase")
+
+	format := req.Format
+	if format == "" {
+		format = detectImportFormat(req.ContentType, req.Data)
+	}
+
+	rows, err := parseImport(format, req.Data)
+	if err != nil {
+		log.WithError(err).Error("Error reading import file")
+		return responses.QuestionImportResponse{}, err
+	}
+	if len(rows) == 0 {
+		return responses.QuestionImportResponse{}, fmt.Errorf("%w: no questions found", ErrInvalidImport)
+	}
+	if len(rows) > maxImportRows {
+		return responses.QuestionImportResponse{}, fmt.Errorf("%w: at most %d questions can be imported at once", ErrInvalidImport, maxImportRows)
+	}
+
+	report := responses.QuestionImportResponse{
+		Format: format,
+		DryRun: req.DryRun,
+		Total:  len(rows),
+	}
+	err = u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		categories := newCategoryResolver(u.categoryRepo)
+		for _, row := range rows {
+			result := responses.ImportRowResponse{Row: row.line, Question: row.req.Question}
+
+			rowErr := row.err
+			if rowErr == nil {
+				var questionID uint
+				questionID, rowErr = u.importQuestion(ctx, &row, categories)
+				if rowErr != nil && !errors.Is(rowErr, ErrInvalidQuestion) {
+					return fmt.Errorf("row %d: %w", row.line, rowErr)
+				}
+				result.QuestionID = questionID
+			}
+
+			if rowErr != nil {
+				result.Error = rowErr.Error()
+				report.Invalid++
+			} else {
+				report.Valid++
+			}
+			report.Rows = append(report.Rows, result)
+		}
+
+		if report.Invalid > 0 || req.DryRun {
+			return errImportRolledBack
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errImportRolledBack) {
+		log.WithError(err).Error("Error importing questions")
+		return responses.QuestionImportResponse{}, err
+	}
+
+	report.Committed = err == nil
+	if !report.Committed {
+		for i := range report.Rows {
+			report.Rows[i].QuestionID = 0
+		}
+	}
+
+	if report.Invalid > 0 {
+		log.Errorf("Import rejected with %d invalid rows", report.Invalid)
+		return report, ErrImportRejected
+	}
+
+	log.Infof("Imported %d questions, committed: %t", report.Valid, report.Committed)
+	return report, nil
+}
+
+// importQuestion creates the question of one import row. Invalid rows
+// return an ErrInvalidQuestion error.
+func (u *QuestionsUseCase) importQuestion(ctx context.Context, row *importRow, categories *categoryResolver) (uint, error) {
+	req := row.req
+	question, err := buildQuestion(&req)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, path := range row.categoryPaths {
+		id, err := categories.resolve(ctx, path)
+		if err != nil {
+			return 0, err
+		}
+		req.CategoryIDs = append(req.CategoryIDs, id)
+	}
+
+	question.Categories, question.Tags, err = u.resolveTaxonomy(ctx, &req)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := u.createQuestion(ctx, question); err != nil {
+		return 0, err
+	}
+	return question.ID, nil
+}
+
+// detectImportFormat guesses the format of data: CSV when the content type
+// says so, otherwise a JSON array, a bundle or an Open Trivia DB response.
+func detectImportFormat(contentType string, data []byte) string {
+	if strings.Contains(contentType, "csv") {
+		return importFormatCSV
+	}
+
+	data = bytes.TrimSpace(bytes.TrimPrefix(data, []byte("\ufeff")))
+	if len(data) > 0 {
+		switch data[0] {
+		case '[':
+			return importFormatJSON
+		case '{':
+			var probe struct {
+				Version *int `json:"version"`
+			}
+			if json.Unmarshal(data, &probe) == nil && probe.Version != nil {
+				return importFormatBundle
+			}
+			return importFormatOpenTrivia
+		}
+	}
+	return importFormatCSV
+}
+
+func parseImport(format string, data []byte) ([]importRow, error) {
+	switch format {
+	case importFormatCSV:
+		return parseCSVImport(data)
+	case importFormatJSON:
+		return parseJSONImport(data)
+	case importFormatOpenTrivia:
+		return parseOpenTriviaImport(data)
+	case importFormatBundle:
+		return parseBundleImport(data)
+	}
+	return nil, fmt.Errorf("%w: unknown format %q, use csv, json, opentdb or bundle", ErrInvalidImport, format)
+}
+
+func parseJSONImport(data []byte) ([]importRow, error) {
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, fmt.Errorf("%w: expected a JSON array of questions: %v", ErrInvalidImport, err)
+	}
+
+	rows := make([]importRow, len(items))
+	for i, item := range items {
+		rows[i].line = i + 1
+		if err := json.Unmarshal(item, &rows[i].req); err != nil {
+			rows[i].err = fmt.Errorf("malformed question: %v", err)
+		}
+	}
+	return rows, nil
+}
+
+// csvColumns maps the header of every supported CSV column, named like the
+// JSON fields of CreateQuestionRequest, to the field it sets. categories
+// holds category paths like bundles do.
+var csvColumns = map[string]func(row *importRow, value string) error{
+	"question": func(row *importRow, value string) error {
+		row.req.Question = value
+		return nil
+	},
+	"type": func(row *importRow, value string) error {
+		row.req.Type = value
+		return nil
+	},
+	"difficulty": func(row *importRow, value string) error {
+		row.req.Difficulty = value
+		return nil
+	},
+	"points": func(row *importRow, value string) (err error) {
+		row.req.Points, err = parseCSVInt(value)
+		return
+	},
+	"options": func(row *importRow, value string) error {
+		row.req.Options = splitCSVList(value)
+		return nil
+	},
+	"correct_option": func(row *importRow, value string) (err error) {
+		row.req.CorrectOption, err = parseCSVInt(value)
+		return
+	},
+	"correct_options": func(row *importRow, value string) error {
+		for _, item := range splitCSVList(value) {
+			index, err := strconv.Atoi(item)
+			if err != nil {
+				return fmt.Errorf("invalid correct option %q", item)
+			}
+			row.req.CorrectOptions = append(row.req.CorrectOptions, index)
+		}
+		return nil
+	},
+	"partial_credit": func(row *importRow, value string) (err error) {
+		if value != "" {
+			row.req.PartialCredit, err = strconv.ParseBool(value)
+		}
+		return
+	},
+	"numeric_answer": func(row *importRow, value string) error {
+		if value == "" {
+			return nil
+		}
+		answer, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", value)
+		}
+		row.req.NumericAnswer = &answer
+		return nil
+	},
+	"tolerance": func(row *importRow, value string) (err error) {
+		if value != "" {
+			row.req.Tolerance, err = strconv.ParseFloat(value, 64)
+		}
+		return
+	},
+	"accepted_answers": func(row *importRow, value string) error {
+		row.req.AcceptedAnswers = splitCSVList(value)
+		return nil
+	},
+	"time_limit_seconds": func(row *importRow, value string) (err error) {
+		row.req.TimeLimitSeconds, err = parseCSVInt(value)
+		return
+	},
+	"explanation": func(row *importRow, value string) error {
+		row.req.Explanation = value
+		return nil
+	},
+	"reference_url": func(row *importRow, value string) error {
+		row.req.ReferenceURL = value
+		return nil
+	},
+	"category_ids": func(row *importRow, value string) error {
+		for _, item := range splitCSVList(value) {
+			id, err := strconv.ParseUint(item, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid category ID %q", item)
+			}
+			row.req.CategoryIDs = append(row.req.CategoryIDs, uint(id))
+		}
+		return nil
+	},
+	"categories": func(row *importRow, value string) error {
+		row.categoryPaths = splitCSVList(value)
+		return nil
+	},
+	"tags": func(row *importRow, value string) error {
+		row.req.Tags = splitCSVList(value)
+		return nil
+	},
+}
+
+// parseCSVImport reads a CSV file whose first line names its columns; see
+// csvColumns. List values are separated by importListSeparator.
+func parseCSVImport(data []byte) ([]importRow, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\ufeff"))))
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: missing CSV header: %v", ErrInvalidImport, err)
+	}
+
+	setters := make([]func(*importRow, string) error, len(header))
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(column))
+		setter, ok := csvColumns[column]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown CSV column %q", ErrInvalidImport, column)
+		}
+		setters[i] = setter
+	}
+
+	var rows []importRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil && !errors.Is(err, csv.ErrFieldCount) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		row := importRow{line: line}
+		for i, value := range record {
+			if i >= len(setters) {
+				break
+			}
+			if err := setters[i](&row, strings.TrimSpace(value)); err != nil && row.err == nil {
+				row.err = fmt.Errorf("column %s: %v", header[i], err)
+			}
+		}
+		if err != nil {
+			row.err = fmt.Errorf("expected %d columns, got %d", len(header), len(record))
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func parseCSVInt(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number %q", value)
+	}
+	return number, nil
+}
+
+func splitCSVList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, importListSeparator) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// openTriviaResponse is the body returned by the Open Trivia DB API
+// (https://opentdb.com/api_config.php) with its default HTML encoding.
+type openTriviaResponse struct {
+	Results []openTriviaQuestion `json:"results"`
+}
+
+type openTriviaQuestion struct {
+	Type             string   `json:"type"`
+	Difficulty       string   `json:"difficulty"`
+	Category         string   `json:"category"`
+	Question         string   `json:"question"`
+	CorrectAnswer    string   `json:"correct_answer"`
+	IncorrectAnswers []string `json:"incorrect_answers"`
+}
+
+// openTriviaDifficulties maps Open Trivia DB difficulties to ours and the
+// points their questions are worth.
+var openTriviaDifficulties = map[string]struct {
+	difficulty string
+	points     int
+}{
+	"easy":   {"facil", 1},
+	"medium": {"medio", 2},
+	"hard":   {"dificil", 3},
+}
+
+func parseOpenTriviaImport(data []byte) ([]importRow, error) {
+	var response openTriviaResponse
+	if err := json.Unmarshal(data, &response); err != nil || response.Results == nil {
+		return nil, fmt.Errorf("%w: expected an Open Trivia DB response with results", ErrInvalidImport)
+	}
+
+	rows := make([]importRow, len(response.Results))
+	for i, item := range response.Results {
+		rows[i].line = i + 1
+		rows[i].req, rows[i].err = fromOpenTrivia(item)
+	}
+	return rows, nil
+}
+
+// fromOpenTrivia converts an Open Trivia DB question. Its category becomes a
+// tag, and the options of multiple choice questions are sorted so the
+// correct one is not always in the same position.
+func fromOpenTrivia(item openTriviaQuestion) (requests.CreateQuestionRequest, error) {
+	level, ok := openTriviaDifficulties[item.Difficulty]
+	if !ok {
+		return requests.CreateQuestionRequest{}, fmt.Errorf("unknown difficulty %q", item.Difficulty)
+	}
+
+	req := requests.CreateQuestionRequest{
+		Question:   html.UnescapeString(item.Question),
+		Difficulty: level.difficulty,
+		Points:     level.points,
+	}
+	if item.Category != "" {
+		req.Tags = []string{html.UnescapeString(item.Category)}
+	}
+
+	correct := html.UnescapeString(item.CorrectAnswer)
+	switch item.Type {
+	case "boolean":
+		req.Type = models.QuestionTrueFalse
+		if !strings.EqualFold(correct, trueFalseOptions[0]) {
+			req.CorrectOption = 1
+		}
+	case "multiple":
+		req.Type = models.QuestionSingleChoice
+		req.Options = []string{correct}
+		for _, answer := range item.IncorrectAnswers {
+			req.Options = append(req.Options, html.UnescapeString(answer))
+		}
+		sort.Strings(req.Options)
+		req.CorrectOption = sort.SearchStrings(req.Options, correct)
+	default:
+		return req, fmt.Errorf("unknown question type %q", item.Type)
+	}
+	return req, nil
+}
@@ -0,0 +1,171 @@
+package questionsusecase
+
+import (
+	"errors"
+	"reflect"
+	"talana_prueba_tecnica/src/entity/models"
+	"talana_prueba_tecnica/src/entity/requests"
+	"testing"
+)
+
+func TestDetectImportFormat(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		data        string
+		want        string
+	}{
+		{"csv content type", "text/csv; charset=utf-8", `[{"question":"?"}]`, importFormatCSV},
+		{"json array", "application/json", `[{"question":"?"}]`, importFormatJSON},
+		{"json array after a BOM and spaces", "", "\ufeff \n[]", importFormatJSON},
+		{"bundle", "application/json", `{"version":1,"questions":[]}`, importFormatBundle},
+		{"open trivia db response", "application/json", `{"response_code":0,"results":[]}`, importFormatOpenTrivia},
+		{"plain text", "text/plain", "question,type\n", importFormatCSV},
+		{"empty", "", "", importFormatCSV},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detectImportFormat(tt.contentType, []byte(tt.data)); got != tt.want {
+				t.Errorf("detectImportFormat = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// parsedRow is the comparable part of an importRow.
+type parsedRow struct {
+	line          int
+	req           requests.CreateQuestionRequest
+	categoryPaths []string
+	failed        bool
+}
+
+func TestParseImport(t *testing.T) {
+	answer := 42.0
+
+	tests := []struct {
+		name    string
+		format  string
+		data    string
+		want    []parsedRow
+		wantErr bool
+	}{
+		{
+			name:   "csv",
+			format: importFormatCSV,
+			data: "\ufeffQuestion, type, difficulty, points, options, correct_option, categories, tags\n" +
+				"Capital of Chile?,single_choice,facil,1,Lima | Santiago,1,Geografía/América,capitals\n",
+			want: []parsedRow{{
+				line: 2,
+				req: requests.CreateQuestionRequest{
+					Question:      "Capital of Chile?",
+					Type:          models.QuestionSingleChoice,
+					Difficulty:    "facil",
+					Points:        1,
+					Options:       []string{"Lima", "Santiago"},
+					CorrectOption: 1,
+					Tags:          []string{"capitals"},
+				},
+				categoryPaths: []string{"Geografía/América"},
+			}},
+		},
+		{
+			name:   "csv numeric answer and invalid row",
+			format: importFormatCSV,
+			data:   "question,type,difficulty,numeric_answer,points\nAnswer?,numeric,medio,42,2\nBroken,numeric,medio,many,2\n",
+			want: []parsedRow{
+				{line: 2, req: requests.CreateQuestionRequest{Question: "Answer?", Type: models.QuestionNumeric, Difficulty: "medio", NumericAnswer: &answer, Points: 2}},
+				{line: 3, req: requests.CreateQuestionRequest{Question: "Broken", Type: models.QuestionNumeric, Difficulty: "medio", Points: 2}, failed: true},
+			},
+		},
+		{
+			name:   "csv row with missing columns",
+			format: importFormatCSV,
+			data:   "question,difficulty\nShort?\n",
+			want:   []parsedRow{{line: 2, req: requests.CreateQuestionRequest{Question: "Short?"}, failed: true}},
+		},
+		{
+			name:    "csv unknown column",
+			format:  importFormatCSV,
+			data:    "question,answer\nWhat?,this\n",
+			wantErr: true,
+		},
+		{
+			name:   "json",
+			format: importFormatJSON,
+			data:   `[{"question":"True?","type":"true_false","difficulty":"facil","points":1}, "not a question"]`,
+			want: []parsedRow{
+				{line: 1, req: requests.CreateQuestionRequest{Question: "True?", Type: models.QuestionTrueFalse, Difficulty: "facil", Points: 1}},
+				{line: 2, failed: true},
+			},
+		},
+		{
+			name:    "json that is not an array",
+			format:  importFormatJSON,
+			data:    `{"question":"True?"}`,
+			wantErr: true,
+		},
+		{
+			name:   "open trivia db",
+			format: importFormatOpenTrivia,
+			data: `{"results":[
+				{"type":"multiple","difficulty":"hard","category":"Science &amp; Nature","question":"Symbol of &quot;gold&quot;?","correct_answer":"Au","incorrect_answers":["Ag","Gd","Go"]},
+				{"type":"boolean","difficulty":"easy","question":"Is Go compiled?","correct_answer":"False","incorrect_answers":["True"]},
+				{"type":"multiple","difficulty":"extreme","question":"?","correct_answer":"a","incorrect_answers":["b"]}
+			]}`,
+			want: []parsedRow{
+				{line: 1, req: requests.CreateQuestionRequest{
+					Question:      `Symbol of "gold"?`,
+					Type:          models.QuestionSingleChoice,
+					Difficulty:    "dificil",
+					Points:        3,
+					Options:       []string{"Ag", "Au", "Gd", "Go"},
+					CorrectOption: 1,
+					Tags:          []string{"Science & Nature"},
+				}},
+				{line: 2, req: requests.CreateQuestionRequest{
+					Question:      "Is Go compiled?",
+					Type:          models.QuestionTrueFalse,
+					Difficulty:    "facil",
+					Points:        1,
+					CorrectOption: 1,
+				}},
+				{line: 3, failed: true},
+			},
+		},
+		{
+			name:    "unknown format",
+			format:  "xml",
+			data:    "<questions/>",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := parseImport(tt.format, []byte(tt.data))
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidImport) {
+					t.Fatalf("parseImport error %v, want %v", err, ErrInvalidImport)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseImport: %v", err)
+			}
+
+			var got []parsedRow
+			for _, row := range rows {
+				parsed := parsedRow{line: row.line, categoryPaths: row.categoryPaths, failed: row.err != nil}
+				// Failed JSON and Open Trivia DB rows keep whatever was decoded
+				// before the error, which the tests do not pin down.
+				if !parsed.failed || tt.format == importFormatCSV {
+					parsed.req = row.req
+				}
+				got = append(got, parsed)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseImport rows\n got %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
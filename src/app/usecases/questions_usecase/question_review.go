@@ -0,0 +1,49 @@
+package questionsusecase
+
+import (
+	"context"
+	"talana_prueba_tecnica/src/entity/models"
+	"talana_prueba_tecnica/src/entity/requests"
+	"talana_prueba_tecnica/src/entity/responses"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ChangeQuestionStatus moves the question through the editorial workflow.
+func (u *QuestionsUseCase) ChangeQuestionStatus(ctx context.Context, id uint, req *requests.StatusChangeRequest) (responses.AuthorQuestionResponse, error) {
+	log := logrus.WithContext(ctx)
+	log.Infof("Changing status of question ID %d usecase", id)
+
+	var question *models.Question
+	err := u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		var err error
+		question, err = u.findQuestion(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := u.reviews.Transition(ctx, models.ContentQuestion, id, question.Status, req); err != nil {
+			return err
+		}
+		if err := u.repository.UpdateStatus(ctx, id, req.Status); err != nil {
+			return err
+		}
+		question.Status = req.Status
+		return nil
+	})
+	if err != nil {
+		log.WithError(err).Error("Error changing question status")
+		return responses.AuthorQuestionResponse{}, err
+	}
+
+	log.Infof("Question ID %d is now %s", id, question.Status)
+	return toAuthorQuestionResponse(question), nil
+}
+
+// QuestionReviews returns the status changes of the question, oldest first.
+func (u *QuestionsUseCase) QuestionReviews(ctx context.Context, id uint) ([]responses.ReviewEventResponse, error) {
+	if _, err := u.findQuestion(ctx, id); err != nil {
+		logrus.WithContext(ctx).WithError(err).Error("Error finding question")
+		return nil, err
+	}
+	return u.reviews.History(ctx, models.ContentQuestion, id)
+}
@@ -0,0 +1,94 @@
+package questionsusecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"talana_prueba_tecnica/src/entity/models"
+	"talana_prueba_tecnica/src/entity/responses"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// QuestionRevisions returns every revision of the question, oldest first,
+// with the changes each one made.
+func (u *QuestionsUseCase) QuestionRevisions(ctx context.Context, id uint) ([]responses.QuestionRevisionResponse, error) {
+	log := logrus.WithContext(ctx)
+	log.Infof("Finding revisions of question ID %d usecase", id)
+
+	if _, err := u.findQuestion(ctx, id); err != nil {
+		log.WithError(err).Error("Error finding question")
+		return nil, err
+	}
+	revisions, err := u.repository.FindRevisions(ctx, id)
+	if err != nil {
+		log.WithError(err).Error("Error finding question revisions")
+		return nil, err
+	}
+
+	result := make([]responses.QuestionRevisionResponse, len(revisions))
+	for i := range revisions {
+		question := revisions[i].Question()
+		result[i] = responses.QuestionRevisionResponse{
+			Revision:  revisions[i].Revision,
+			CreatedAt: revisions[i].CreatedAt,
+			Question:  toAuthorQuestionResponse(&question),
+			Changes:   []responses.FieldChangeResponse{},
+		}
+		if i > 0 {
+			result[i].Changes = diffSnapshots(&revisions[i-1].Snapshot, &revisions[i].Snapshot)
+		}
+	}
+
+	log.Infof("Found %d revisions", len(result))
+	return result, nil
+}
+
+// createQuestion creates question as its first revision.
+func (u *QuestionsUseCase) createQuestion(ctx context.Context, question *models.Question) error {
+	question.Revision = 1
+	return u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if err := u.repository.CreateQuestion(ctx, question); err != nil {
+			return err
+		}
+		return u.repository.CreateRevision(ctx, models.NewQuestionRevision(question))
+	})
+}
+
+func (u *QuestionsUseCase) findQuestion(ctx context.Context, id uint) (*models.Question, error) {
+	question, err := u.repository.FindByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrQuestionNotFound
+	}
+	return question, err
+}
+
+// diffSnapshots lists the fields changed from one snapshot to the next.
+func diffSnapshots(from, to *models.QuestionSnapshot) []responses.FieldChangeResponse {
+	fields := []struct {
+		name     string
+		from, to interface{}
+	}{
+		{"question", from.Question, to.Question},
+		{"type", from.Type, to.Type},
+		{"options", from.Options, to.Options},
+		{"correct_option", from.CorrectOption, to.CorrectOption},
+		{"partial_credit", from.PartialCredit, to.PartialCredit},
+		{"numeric_answer", from.NumericAnswer, to.NumericAnswer},
+		{"tolerance", from.Tolerance, to.Tolerance},
+		{"difficulty", from.Difficulty, to.Difficulty},
+		{"points", from.Points, to.Points},
+		{"time_limit_seconds", from.TimeLimitSeconds, to.TimeLimitSeconds},
+		{"explanation", from.Explanation, to.Explanation},
+		{"reference_url", from.ReferenceURL, to.ReferenceURL},
+	}
+
+	changes := []responses.FieldChangeResponse{}
+	for _, field := range fields {
+		if !reflect.DeepEqual(field.from, field.to) {
+			changes = append(changes, responses.FieldChangeResponse{Field: field.name, From: field.from, To: field.to})
+		}
+	}
+	return changes
+}
@@ -3,29 +3,60 @@ package questionsusecase
 import (
 	"context"
 	"errors"
+	reviewusecase "talana_prueba_tecnica/src/app/usecases/review_usecase"
 	"talana_prueba_tecnica/src/entity/models"
 	"talana_prueba_tecnica/src/entity/requests"
 	"talana_prueba_tecnica/src/entity/responses"
+	categoryrepository "talana_prueba_tecnica/src/infraestructure/repository/category_repository"
 	questionsrepository "talana_prueba_tecnica/src/infraestructure/repository/questions_repository"
+	tagrepository "talana_prueba_tecnica/src/infraestructure/repository/tag_repository"
+	"talana_prueba_tecnica/src/shared"
 
 	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidDifficulty = errors.New("difficulty must be one of facil, medio, dificil")
+	ErrQuestionNotFound  = errors.New("question not found")
+	ErrQuestionPlayed    = errors.New("question was played and cannot be purged")
 )
 
 type QuestionsUseCase struct {
-	repository questionsrepository.QuestionRepositoryInterface
+	repository   questionsrepository.QuestionRepositoryInterface
+	categoryRepo categoryrepository.CategoryRepositoryInterface
+	tagRepo      tagrepository.TagRepositoryInterface
+	reviews      reviewusecase.ReviewUseCaseInterface
+	unitOfWork   shared.UnitOfWork
 }
 
-func NewQuestionsUseCase(repository questionsrepository.QuestionRepositoryInterface) *QuestionsUseCase {
+func NewQuestionsUseCase(
+	repository questionsrepository.QuestionRepositoryInterface,
+	categoryRepo categoryrepository.CategoryRepositoryInterface,
+	tagRepo tagrepository.TagRepositoryInterface,
+	reviews reviewusecase.ReviewUseCaseInterface,
+	unitOfWork shared.UnitOfWork,
+) *QuestionsUseCase {
 	return &QuestionsUseCase{
-		repository: repository,
+		repository:   repository,
+		categoryRepo: categoryRepo,
+		tagRepo:      tagRepo,
+		reviews:      reviews,
+		unitOfWork:   unitOfWork,
 	}
 
 }
-func (u *QuestionsUseCase) FindAll(ctx context.Context) ([]responses.QuestionResponse, error) {
+func (u *QuestionsUseCase) FindAll(ctx context.Context, query requests.QuestionQuery) ([]responses.QuestionResponse, error) {
 	log := logrus.WithContext(ctx)
 	log.Info("Get all questions usecase")
 
-	result, err := u.repository.FindAll(ctx)
+	filter, err := toQuestionFilter(query)
+	if err != nil {
+		log.WithError(err).Error("Invalid question filter")
+		return nil, err
+	}
+
+	result, err := u.repository.FindAll(ctx, filter)
 	if err != nil {
 		log.Errorf("Error: %v", err)
 		return nil, err
@@ -36,19 +67,23 @@ func (u *QuestionsUseCase) FindAll(ctx context.Context) ([]responses.QuestionRes
 
 	for _, question := range result {
 		var optionsList []responses.OptionResponse
-		for _, option := range question.Options {
+		for i, option := range question.PlayerOptions() {
 			optionsList = append(optionsList, responses.OptionResponse{
-				ID:     option.ID,
+				Index:  i,
 				Option: option.Text,
 			})
 		}
 
 		responseQuestion := responses.QuestionResponse{
-			ID:            question.ID,
-			Question:      question.Question,
-			CorrectOption: question.CorrectOption,
-			Options:       optionsList,
-			Difficulty:    question.Difficulty,
+			ID:               question.ID,
+			Question:         question.Question,
+			Type:             question.Type,
+			Options:          optionsList,
+			Difficulty:       question.Difficulty,
+			TimeLimitSeconds: question.TimeLimitSeconds,
+			Status:           question.Status,
+			Categories:       toCategoryResponses(question.Categories),
+			Tags:             tagNames(question.Tags),
 		}
 
 		questionsList = append(questionsList, responseQuestion)
@@ -57,32 +92,42 @@ func (u *QuestionsUseCase) FindAll(ctx context.Context) ([]responses.QuestionRes
 	return questionsList, nil
 }
 
-func (u *QuestionsUseCase) FindByID(ctx context.Context, id uint) (responses.QuestionResponse, error) {
+// FindByID returns the question with id; with publishedOnly, unpublished
+// questions are reported as not found.
+func (u *QuestionsUseCase) FindByID(ctx context.Context, id uint, publishedOnly bool) (responses.QuestionResponse, error) {
 	log := logrus.WithContext(ctx)
 	log.Infof("Get question by ID: %d usecase", id)
 
-	result, err := u.repository.FindByID(ctx, id)
+	result, err := u.findQuestion(ctx, id)
 	if err != nil {
 		log.Errorf("Error: %v", err)
 		return responses.QuestionResponse{}, err
 	}
+	if publishedOnly && result.Status != models.StatusPublished {
+		log.Errorf("Question ID %d is not published", id)
+		return responses.QuestionResponse{}, ErrQuestionNotFound
+	}
 
 	log.Info("Question found")
 
 	var optionsList []responses.OptionResponse
-	for _, option := range result.Options {
+	for i, option := range result.PlayerOptions() {
 		optionsList = append(optionsList, responses.OptionResponse{
-			ID:     option.ID,
+			Index:  i,
 			Option: option.Text,
 		})
 	}
 
 	responseQuestion := responses.QuestionResponse{
-		ID:            result.ID,
-		Question:      result.Question,
-		CorrectOption: result.CorrectOption,
-		Options:       optionsList,
-		Difficulty:    result.Difficulty,
+		ID:               result.ID,
+		Question:         result.Question,
+		Type:             result.Type,
+		Options:          optionsList,
+		Difficulty:       result.Difficulty,
+		TimeLimitSeconds: result.TimeLimitSeconds,
+		Status:           result.Status,
+		Categories:       toCategoryResponses(result.Categories),
+		Tags:             tagNames(result.Tags),
 	}
 
 	return responseQuestion, nil
@@ -92,33 +137,25 @@ func (u *QuestionsUseCase) CreateQuestion(ctx context.Context, req *requests.Cre
 	log := logrus.WithContext(ctx)
 	log.Info("Creating question in usecase")
 
-	if len(req.Options) < 2 {
-		log.Errorf("at least two options are required")
-		return errors.New("at least two options are required")
-	}
-
-	if req.CorrectOption >= len(req.Options) || req.CorrectOption < 0 {
-		log.Errorf("invalid correct option index")
-		return errors.New("invalid correct option index")
-	}
-
-	var options []models.Option
-
-	for _, opt := range req.Options {
-		options = append(options, models.Option{Text: opt})
+	question, err := buildQuestion(req)
+	if err != nil {
+		log.WithError(err).Error("Invalid question")
+		return err
 	}
 
-	question := &models.Question{
-		Question:      req.Question,
-		Difficulty:    req.Difficulty,
-		Points:        req.Points,
-		CorrectOption: uint(req.CorrectOption),
-		Options:       options,
-	}
+	err = u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		question.Categories, question.Tags, err = u.resolveTaxonomy(ctx, req)
+		if err != nil {
+			return err
+		}
 
-	err := u.repository.CreateQuestion(ctx, question)
+		if err := u.createQuestion(ctx, question); err != nil {
+			log.WithError(err).Error("Error creating question in repository")
+			return err
+		}
+		return nil
+	})
 	if err != nil {
-		log.WithError(err).Error("Error creating question in repository")
 		return err
 	}
 
@@ -130,31 +167,52 @@ func (u *QuestionsUseCase) UpdateQuestion(ctx context.Context, req *requests.Cre
 	log := logrus.WithContext(ctx)
 	log.Info("Updating question in usecase")
 
-	if len(req.Options) < 2 {
-		log.Errorf("at least two options are required")
-		return errors.New("at least two options are required")
+	question, err := buildQuestion(req)
+	if err != nil {
+		log.WithError(err).Error("Invalid question")
+		return err
 	}
+	options := question.Options
+	question.Options = nil
 
-	if req.CorrectOption >= len(req.Options) || req.CorrectOption < 0 {
-		log.Errorf("invalid correct option index")
-		return errors.New("invalid correct option index")
-	}
+	err = u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		current, err := u.findQuestion(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := reviewusecase.EnsureEditable(current.Status); err != nil {
+			return err
+		}
+		question.Revision = current.Revision + 1
 
-	question := &models.Question{
-		Question:      req.Question,
-		Difficulty:    req.Difficulty,
-		Points:        req.Points,
-		CorrectOption: uint(req.CorrectOption),
-		Options:       make([]models.Option, len(req.Options)),
-	}
+		if err := u.repository.UpdateQuestion(ctx, question, id); err != nil {
+			log.WithError(err).Error("Error updating question in repository")
+			return err
+		}
 
-	for _, opt := range req.Options {
-		question.Options = append(question.Options, models.Option{Text: opt})
-	}
+		if err := u.repository.ReplaceOptions(ctx, id, options); err != nil {
+			log.WithError(err).Error("Error replacing question options in repository")
+			return err
+		}
+
+		categories, tags, err := u.resolveTaxonomy(ctx, req)
+		if err != nil {
+			return err
+		}
+		if err := u.repository.ReplaceTaxonomy(ctx, id, categories, tags); err != nil {
+			log.WithError(err).Error("Error replacing question categories and tags in repository")
+			return err
+		}
 
-	err := u.repository.UpdateQuestion(ctx, question, id)
+		question.ID = id
+		question.Options = options
+		if err := u.repository.CreateRevision(ctx, models.NewQuestionRevision(question)); err != nil {
+			log.WithError(err).Error("Error creating question revision in repository")
+			return err
+		}
+		return nil
+	})
 	if err != nil {
-		log.WithError(err).Error("Error updating question in repository")
 		return err
 	}
 
@@ -166,28 +224,67 @@ func (u *QuestionsUseCase) DeleteQuestion(ctx context.Context, id uint) error {
 	log := logrus.WithContext(ctx)
 	log.Info("Delete question usecase")
 
-	questionExists, err := u.repository.FindByID(ctx, id)
+	if _, err := u.findQuestion(ctx, id); err != nil {
+		log.Errorf("Error: %v", err)
+		return err
+	}
+
+	err := u.repository.DeleteQuestion(ctx, id)
 	if err != nil {
 		log.Errorf("Error: %v", err)
 		return err
 	}
 
-	if questionExists.ID == 0 {
-		log.Errorf("Question not found")
-		return errors.New("Question not found")
+	log.Info("Question deleted successfully")
+	return nil
+}
+
+// RestoreQuestion brings back a deleted question.
+func (u *QuestionsUseCase) RestoreQuestion(ctx context.Context, id uint) error {
+	log := logrus.WithContext(ctx)
+	log.Infof("Restore question ID %d usecase", id)
+
+	err := u.repository.RestoreQuestion(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Error("Deleted question not found")
+		return ErrQuestionNotFound
 	}
+	if err != nil {
+		log.WithError(err).Error("Error restoring question")
+		return err
+	}
+
+	log.Info("Question restored successfully")
+	return nil
+}
+
+// PurgeQuestion permanently removes a deleted question and the answers
+// given to it.
+func (u *QuestionsUseCase) PurgeQuestion(ctx context.Context, id uint) error {
+	log := logrus.WithContext(ctx)
+	log.Infof("Purge question ID %d usecase", id)
 
-	err = u.repository.DeleteQuestion(ctx, id)
+	err := u.repository.PurgeQuestion(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Error("Deleted question not found")
+		return ErrQuestionNotFound
+	}
+	if errors.Is(err, questionsrepository.ErrQuestionPlayed) {
+		log.Error("Question was played")
+		return ErrQuestionPlayed
+	}
 	if err != nil {
-		log.Errorf("Error: %v", err)
+		log.WithError(err).Error("Error purging question")
 		return err
 	}
 
-	log.Info("Question deleted successfully")
+	log.Info("Question purged successfully")
 	return nil
 }
 
-func (u *QuestionsUseCase) FullTextSearch(ctx context.Context, query string) ([]responses.QuestionResponse, error) {
+// FullTextSearch returns the questions matching query; with publishedOnly,
+// only the published ones.
+func (u *QuestionsUseCase) FullTextSearch(ctx context.Context, query string, publishedOnly bool) ([]responses.QuestionResponse, error) {
 	log := logrus.WithContext(ctx)
 	log.Infof("Performing full text search with query: %s", query)
 
@@ -201,20 +298,28 @@ func (u *QuestionsUseCase) FullTextSearch(ctx context.Context, query string) ([]
 	var questionsList []responses.QuestionResponse
 
 	for _, question := range result {
+		if publishedOnly && question.Status != models.StatusPublished {
+			continue
+		}
+
 		var optionsList []responses.OptionResponse
-		for _, option := range question.Options {
+		for i, option := range question.PlayerOptions() {
 			optionsList = append(optionsList, responses.OptionResponse{
-				ID:     option.ID,
+				Index:  i,
 				Option: option.Text,
 			})
 		}
 
 		responseQuestion := responses.QuestionResponse{
-			ID:            question.ID,
-			Question:      question.Question,
-			CorrectOption: question.CorrectOption,
-			Options:       optionsList,
-			Difficulty:    question.Difficulty,
+			ID:               question.ID,
+			Question:         question.Question,
+			Type:             question.Type,
+			Options:          optionsList,
+			Difficulty:       question.Difficulty,
+			TimeLimitSeconds: question.TimeLimitSeconds,
+			Status:           question.Status,
+			Categories:       toCategoryResponses(question.Categories),
+			Tags:             tagNames(question.Tags),
 		}
 
 		questionsList = append(questionsList, responseQuestion)
@@ -222,3 +327,179 @@ func (u *QuestionsUseCase) FullTextSearch(ctx context.Context, query string) ([]
 
 	return questionsList, nil
 }
+
+func (u *QuestionsUseCase) FindAllForAuthor(ctx context.Context, query requests.QuestionQuery) ([]responses.AuthorQuestionResponse, error) {
+	log := logrus.WithContext(ctx)
+	log.Info("Get all questions for author usecase")
+
+	filter, err := toQuestionFilter(query)
+	if err != nil {
+		log.WithError(err).Error("Invalid question filter")
+		return nil, err
+	}
+
+	result, err := u.repository.FindAll(ctx, filter)
+	if err != nil {
+		log.Errorf("Error: %v", err)
+		return nil, err
+	}
+
+	log.Info("Questions found")
+	var questionsList []responses.AuthorQuestionResponse
+	for _, question := range result {
+		questionsList = append(questionsList, toAuthorQuestionResponse(&question))
+	}
+
+	return questionsList, nil
+}
+
+func (u *QuestionsUseCase) FindByIDForAuthor(ctx context.Context, id uint) (responses.AuthorQuestionResponse, error) {
+	log := logrus.WithContext(ctx)
+	log.Infof("Get question by ID: %d for author usecase", id)
+
+	result, err := u.repository.FindByID(ctx, id)
+	if err != nil {
+		log.Errorf("Error: %v", err)
+		return responses.AuthorQuestionResponse{}, err
+	}
+
+	log.Info("Question found")
+	return toAuthorQuestionResponse(result), nil
+}
+
+func toAuthorQuestionResponse(question *models.Question) responses.AuthorQuestionResponse {
+	var optionsList []responses.OptionResponse
+	for i, option := range question.Options {
+		optionsList = append(optionsList, responses.OptionResponse{
+			Index:  i,
+			Option: option.Text,
+		})
+	}
+
+	var correctOptions []uint
+	var acceptedAnswers []string
+	for i, option := range question.Options {
+		if option.IsCorrect {
+			correctOptions = append(correctOptions, uint(i))
+		}
+		if question.Type == models.QuestionFreeText {
+			acceptedAnswers = append(acceptedAnswers, option.Text)
+		}
+	}
+
+	return responses.AuthorQuestionResponse{
+		ID:               question.ID,
+		Question:         question.Question,
+		Type:             question.Type,
+		CorrectOption:    question.CorrectOption,
+		CorrectOptions:   correctOptions,
+		PartialCredit:    question.PartialCredit,
+		NumericAnswer:    question.NumericAnswer,
+		Tolerance:        question.Tolerance,
+		AcceptedAnswers:  acceptedAnswers,
+		Options:          optionsList,
+		Difficulty:       question.Difficulty,
+		Points:           question.Points,
+		TimeLimitSeconds: question.TimeLimitSeconds,
+		Explanation:      question.Explanation,
+		ReferenceURL:     question.ReferenceURL,
+		Revision:         question.Revision,
+		Status:           question.Status,
+		Categories:       toCategoryResponses(question.Categories),
+		Tags:             tagNames(question.Tags),
+	}
+}
+
+// resolveTaxonomy loads the categories of req and finds or creates its tags.
+func (u *QuestionsUseCase) resolveTaxonomy(ctx context.Context, req *requests.CreateQuestionRequest) ([]models.Category, []models.Tag, error) {
+	log := logrus.WithContext(ctx)
+
+	categoryIDs := map[uint]bool{}
+	var ids []uint
+	for _, id := range req.CategoryIDs {
+		if !categoryIDs[id] {
+			categoryIDs[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	var categories []models.Category
+	if len(ids) > 0 {
+		var err error
+		categories, err = u.categoryRepo.FindByIDs(ctx, ids)
+		if err != nil {
+			log.WithError(err).Error("Error finding question categories")
+			return nil, nil, err
+		}
+		if len(categories) != len(ids) {
+			log.Errorf("Unknown category in %v", ids)
+			return nil, nil, invalidQuestion("unknown category")
+		}
+	}
+
+	tagNames := map[string]bool{}
+	var names []string
+	for _, tag := range req.Tags {
+		name := models.NormalizeTagName(tag)
+		if name == "" || tagNames[name] {
+			continue
+		}
+		if len(name) > models.MaxTagNameLength {
+			return nil, nil, invalidQuestion("tags must be at most 50 characters")
+		}
+		tagNames[name] = true
+		names = append(names, name)
+	}
+
+	tags, err := u.tagRepo.FindOrCreate(ctx, names)
+	if err != nil {
+		log.WithError(err).Error("Error resolving question tags")
+		return nil, nil, err
+	}
+
+	return categories, tags, nil
+}
+
+func toQuestionFilter(query requests.QuestionQuery) (questionsrepository.QuestionFilter, error) {
+	if query.Difficulty != "" && !isDifficulty(query.Difficulty) {
+		return questionsrepository.QuestionFilter{}, ErrInvalidDifficulty
+	}
+	if query.Status != "" && !models.IsValidStatus(query.Status) {
+		return questionsrepository.QuestionFilter{}, reviewusecase.ErrInvalidStatus
+	}
+
+	return questionsrepository.QuestionFilter{
+		CategoryID: query.CategoryID,
+		Tag:        models.NormalizeTagName(query.Tag),
+		Difficulty: query.Difficulty,
+		Status:     query.Status,
+	}, nil
+}
+
+func isDifficulty(difficulty string) bool {
+	switch difficulty {
+	case "facil", "medio", "dificil":
+		return true
+	}
+	return false
+}
+
+func toCategoryResponses(categories []models.Category) []responses.CategoryResponse {
+	var result []responses.CategoryResponse
+	for _, category := range categories {
+		result = append(result, responses.CategoryResponse{
+			ID:       category.ID,
+			Name:     category.Name,
+			ParentID: category.ParentID,
+		})
+	}
+	return result
+}
+
+func tagNames(tags []models.Tag) []string {
+	var names []string
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+	return names
+}
@@ -7,10 +7,19 @@ import (
 )
 
 type QuestionUseCaseInterface interface {
-	FindAll(ctx context.Context) ([]responses.QuestionResponse, error)
-	FindByID(ctx context.Context, id uint) (responses.QuestionResponse, error)
+	FindAll(ctx context.Context, query requests.QuestionQuery) ([]responses.QuestionResponse, error)
+	FindByID(ctx context.Context, id uint, publishedOnly bool) (responses.QuestionResponse, error)
+	FindAllForAuthor(ctx context.Context, query requests.QuestionQuery) ([]responses.AuthorQuestionResponse, error)
+	FindByIDForAuthor(ctx context.Context, id uint) (responses.AuthorQuestionResponse, error)
 	CreateQuestion(ctx context.Context, req *requests.CreateQuestionRequest) error
+	ImportQuestions(ctx context.Context, req *requests.ImportQuestionsRequest) (responses.QuestionImportResponse, error)
+	ExportQuestions(ctx context.Context, query requests.QuestionQuery) (responses.ContentBundle, error)
 	UpdateQuestion(ctx context.Context, req *requests.CreateQuestionRequest, id uint) error
+	QuestionRevisions(ctx context.Context, id uint) ([]responses.QuestionRevisionResponse, error)
+	ChangeQuestionStatus(ctx context.Context, id uint, req *requests.StatusChangeRequest) (responses.AuthorQuestionResponse, error)
+	QuestionReviews(ctx context.Context, id uint) ([]responses.ReviewEventResponse, error)
 	DeleteQuestion(ctx context.Context, id uint) error
-	FullTextSearch(ctx context.Context, search string) ([]responses.QuestionResponse, error)
+	RestoreQuestion(ctx context.Context, id uint) error
+	PurgeQuestion(ctx context.Context, id uint) error
+	FullTextSearch(ctx context.Context, search string, publishedOnly bool) ([]responses.QuestionResponse, error)
 }
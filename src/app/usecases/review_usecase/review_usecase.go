@@ -0,0 +1,112 @@
+package reviewusecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"talana_prueba_tecnica/src/entity/models"
+	"talana_prueba_tecnica/src/entity/requests"
+	"talana_prueba_tecnica/src/entity/responses"
+	reviewrepository "talana_prueba_tecnica/src/infraestructure/repository/review_repository"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrInvalidStatus     = errors.New("status must be one of draft, in_review, published, archived")
+	ErrInvalidTransition = errors.New("status change not allowed")
+	ErrReviewerRequired  = errors.New("only reviewers can approve or reject content in review")
+	ErrCommentRequired   = errors.New("a comment is required when rejecting content")
+	ErrNotEditable       = errors.New("only drafts can be edited")
+)
+
+// ReviewUseCase enforces the editorial workflow shared by questions and
+// trivias and keeps the history of their status changes. Admins are the
+// reviewers.
+type ReviewUseCase struct {
+	repository reviewrepository.ReviewRepositoryInterface
+}
+
+func NewReviewUseCase(repository reviewrepository.ReviewRepositoryInterface) *ReviewUseCase {
+	return &ReviewUseCase{repository: repository}
+}
+
+// Transition checks that content in status from may move as req asks and
+// records the change. Changing the status of the content itself is up to
+// the caller, in the same unit of work.
+func (u *ReviewUseCase) Transition(ctx context.Context, contentType string, contentID uint, from string, req *requests.StatusChangeRequest) error {
+	log := logrus.WithContext(ctx)
+	log.Infof("Moving %s ID %d from %s to %s usecase", contentType, contentID, from, req.Status)
+
+	if !models.IsValidStatus(req.Status) {
+		log.Errorf("Invalid status %q", req.Status)
+		return ErrInvalidStatus
+	}
+	if !models.CanTransition(from, req.Status) {
+		log.Errorf("Transition from %s to %s not allowed", from, req.Status)
+		return fmt.Errorf("%w: %s cannot move from %s to %s", ErrInvalidTransition, contentType, from, req.Status)
+	}
+
+	comment := strings.TrimSpace(req.Comment)
+	if from == models.StatusInReview {
+		if !req.Reviewer {
+			log.Errorf("User ID %d is not a reviewer", req.UserID)
+			return ErrReviewerRequired
+		}
+		if req.Status == models.StatusDraft && comment == "" {
+			log.Error("Rejection without comment")
+			return ErrCommentRequired
+		}
+	}
+
+	event := &models.ReviewEvent{
+		ContentType: contentType,
+		ContentID:   contentID,
+		FromStatus:  from,
+		ToStatus:    req.Status,
+		Comment:     comment,
+		UserID:      req.UserID,
+	}
+	if err := u.repository.CreateEvent(ctx, event); err != nil {
+		log.WithError(err).Error("Error recording review event")
+		return err
+	}
+
+	log.Info("Status change recorded")
+	return nil
+}
+
+// History returns the status changes of the content, oldest first.
+func (u *ReviewUseCase) History(ctx context.Context, contentType string, contentID uint) ([]responses.ReviewEventResponse, error) {
+	log := logrus.WithContext(ctx)
+	log.Infof("Getting review history of %s ID %d usecase", contentType, contentID)
+
+	events, err := u.repository.FindEvents(ctx, contentType, contentID)
+	if err != nil {
+		log.WithError(err).Error("Error finding review events")
+		return nil, err
+	}
+
+	result := []responses.ReviewEventResponse{}
+	for _, event := range events {
+		result = append(result, responses.ReviewEventResponse{
+			ID:         event.ID,
+			FromStatus: event.FromStatus,
+			ToStatus:   event.ToStatus,
+			Comment:    event.Comment,
+			UserID:     event.UserID,
+			CreatedAt:  event.CreatedAt,
+		})
+	}
+	return result, nil
+}
+
+// EnsureEditable returns ErrNotEditable unless content in status may be
+// edited.
+func EnsureEditable(status string) error {
+	if status != models.StatusDraft {
+		return ErrNotEditable
+	}
+	return nil
+}
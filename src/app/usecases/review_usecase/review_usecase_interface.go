@@ -0,0 +1,12 @@
+package reviewusecase
+
+import (
+	"context"
+	"talana_prueba_tecnica/src/entity/requests"
+	"talana_prueba_tecnica/src/entity/responses"
+)
+
+type ReviewUseCaseInterface interface {
+	Transition(ctx context.Context, contentType string, contentID uint, from string, req *requests.StatusChangeRequest) error
+	History(ctx context.Context, contentType string, contentID uint) ([]responses.ReviewEventResponse, error)
+}
@@ -0,0 +1,151 @@
+package roomusecase
+
+import (
+	"sort"
+	"sync"
+	"talana_prueba_tecnica/src/entity/responses"
+	"time"
+)
+
+const (
+	RoomStatusWaiting  = "waiting"
+	RoomStatusPlaying  = "playing"
+	RoomStatusFinished = "finished"
+)
+
+// Player is the connection of a room participant. Send is called from
+// several goroutines, so implementations must be safe for concurrent use.
+type Player interface {
+	Send(event responses.RoomEvent) error
+}
+
+type roomPlayer struct {
+	userID         uint
+	name           string
+	conn           Player
+	sessionID      uint
+	score          int
+	correctAnswers int
+	answeredRound  int
+}
+
+type room struct {
+	mu           sync.Mutex
+	code         string
+	triviaID     uint
+	hostID       uint
+	hostConn     Player
+	questionTime time.Duration
+	status       string
+	starting     bool
+	round        int
+	totalRounds  int
+	roundDone    chan struct{}
+	players      map[uint]*roomPlayer
+}
+
+// broadcast must be called with r.mu held.
+func (r *room) broadcast(event responses.RoomEvent) {
+	if r.hostConn != nil {
+		_ = r.hostConn.Send(event)
+	}
+	for _, player := range r.players {
+		if player.conn != nil {
+			_ = player.conn.Send(event)
+		}
+	}
+}
+
+// playing returns the players taking part in the game. It must be called
+// with r.mu held.
+func (r *room) playing() []*roomPlayer {
+	var players []*roomPlayer
+	for _, player := range r.players {
+		if player.sessionID != 0 {
+			players = append(players, player)
+		}
+	}
+	return players
+}
+
+// closeRoundIfDone ends the current round once every player has answered. It
+// must be called with r.mu held.
+func (r *room) closeRoundIfDone() {
+	if r.roundDone == nil {
+		return
+	}
+	for _, player := range r.playing() {
+		if player.answeredRound < r.round {
+			return
+		}
+	}
+	close(r.roundDone)
+	r.roundDone = nil
+}
+
+// connected reports whether anyone is still connected. It must be called
+// with r.mu held.
+func (r *room) connected() bool {
+	if r.hostConn != nil {
+		return true
+	}
+	for _, player := range r.players {
+		if player.conn != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// leaderboard must be called with r.mu held.
+func (r *room) leaderboard() []responses.LeaderboardEntryResponse {
+	players := r.playing()
+	sort.Slice(players, func(i, j int) bool {
+		if players[i].score != players[j].score {
+			return players[i].score > players[j].score
+		}
+		if players[i].correctAnswers != players[j].correctAnswers {
+			return players[i].correctAnswers > players[j].correctAnswers
+		}
+		return players[i].userID < players[j].userID
+	})
+
+	var entries []responses.LeaderboardEntryResponse
+	for i, player := range players {
+		rank := i + 1
+		if i > 0 && player.score == players[i-1].score {
+			rank = entries[i-1].Rank
+		}
+		entries = append(entries, responses.LeaderboardEntryResponse{
+			Rank:           rank,
+			UserID:         player.userID,
+			Name:           player.name,
+			Score:          player.score,
+			CorrectAnswers: player.correctAnswers,
+		})
+	}
+	return entries
+}
+
+// snapshot must be called with r.mu held.
+func (r *room) snapshot() responses.RoomResponse {
+	response := responses.RoomResponse{
+		Code:         r.code,
+		TriviaID:     r.triviaID,
+		HostID:       r.hostID,
+		Status:       r.status,
+		CurrentRound: r.round,
+		TotalRounds:  r.totalRounds,
+	}
+	for _, player := range r.players {
+		response.Players = append(response.Players, responses.RoomPlayerResponse{
+			UserID:    player.userID,
+			Name:      player.name,
+			Connected: player.conn != nil,
+		})
+	}
+	sort.Slice(response.Players, func(i, j int) bool {
+		return response.Players[i].UserID < response.Players[j].UserID
+	})
+	return response
+}
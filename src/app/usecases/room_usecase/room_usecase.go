@@ -0,0 +1,461 @@
+package roomusecase
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"sync"
+	gameusecase "talana_prueba_tecnica/src/app/usecases/game_usecase"
+	"talana_prueba_tecnica/src/entity/models"
+	"talana_prueba_tecnica/src/entity/requests"
+	"talana_prueba_tecnica/src/entity/responses"
+	triviarepository "talana_prueba_tecnica/src/infraestructure/repository/trivia_repository"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrRoomNotFound     = errors.New("room not found")
+	ErrRoomStarted      = errors.New("room already started")
+	ErrRoomFinished     = errors.New("room already finished")
+	ErrRoomNotPlaying   = errors.New("room is not playing")
+	ErrNotAllowed       = errors.New("user is not assigned to this trivia")
+	ErrNotHost          = errors.New("only the host can start the room")
+	ErrNoPlayers        = errors.New("no players connected to the room")
+	ErrAlreadyAnswered  = errors.New("question already answered in this round")
+	ErrUnknownMessage   = errors.New("unknown message type")
+	ErrInvalidRoomTimer = errors.New("question_seconds cannot be negative")
+)
+
+const (
+	MessageStart  = "start"
+	MessageAnswer = "answer"
+
+	EventPlayerJoined = "player_joined"
+	EventPlayerLeft   = "player_left"
+	EventStarted      = "started"
+	EventQuestion     = "question"
+	EventAnswerResult = "answer_result"
+	EventLeaderboard  = "leaderboard"
+	EventFinished     = "finished"
+	EventError        = "error"
+)
+
+const (
+	defaultQuestionSeconds = 20
+	roomCodeLength         = 6
+	roomCodeAlphabet       = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+)
+
+type RoomUseCase struct {
+	gameUseCase gameusecase.GameUseCaseInterface
+	triviaRepo  triviarepository.TriviaRepositoryInterface
+
+	mu    sync.Mutex
+	rooms map[string]*room
+}
+
+func NewRoomUseCase(
+	gameUseCase gameusecase.GameUseCaseInterface,
+	triviaRepository triviarepository.TriviaRepositoryInterface,
+) *RoomUseCase {
+	return &RoomUseCase{
+		gameUseCase: gameUseCase,
+		triviaRepo:  triviaRepository,
+		rooms:       make(map[string]*room),
+	}
+}
+
+func (u *RoomUseCase) CreateRoom(ctx context.Context, req *requests.CreateRoomRequest) (responses.RoomResponse, error) {
+	log := logrus.WithContext(ctx)
+	log.Infof("Creating room for trivia ID %d usecase", req.TriviaID)
+
+	if req.HostID == 0 {
+		log.Error("No host provided")
+		return responses.RoomResponse{}, errors.New("host_id is required")
+	}
+	if req.QuestionSeconds < 0 {
+		log.Error("Negative question time")
+		return responses.RoomResponse{}, ErrInvalidRoomTimer
+	}
+
+	trivia, err := u.triviaRepo.FindByID(ctx, req.TriviaID)
+	if err != nil {
+		log.WithError(err).Error("Trivia not found")
+		return responses.RoomResponse{}, errors.New("trivia not found")
+	}
+	if trivia.Status != models.StatusPublished {
+		log.Errorf("Trivia ID %d is %s", trivia.ID, trivia.Status)
+		return responses.RoomResponse{}, gameusecase.ErrTriviaNotPublished
+	}
+
+	questionSeconds := req.QuestionSeconds
+	if questionSeconds == 0 {
+		questionSeconds = defaultQuestionSeconds
+	}
+
+	r := &room{
+		triviaID:     trivia.ID,
+		hostID:       req.HostID,
+		questionTime: time.Duration(questionSeconds) * time.Second,
+		status:       RoomStatusWaiting,
+		totalRounds:  trivia.ServedQuestionCount(),
+		players:      make(map[uint]*roomPlayer),
+	}
+	for _, user := range trivia.Users {
+		r.players[user.ID] = &roomPlayer{userID: user.ID, name: user.Name}
+	}
+
+	u.mu.Lock()
+	for {
+		r.code, err = newRoomCode()
+		if err != nil {
+			u.mu.Unlock()
+			log.WithError(err).Error("Error generating room code")
+			return responses.RoomResponse{}, err
+		}
+		if _, exists := u.rooms[r.code]; !exists {
+			break
+		}
+	}
+	u.rooms[r.code] = r
+	u.mu.Unlock()
+
+	log.Infof("Room %s created", r.code)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.snapshot(), nil
+}
+
+func (u *RoomUseCase) GetRoom(ctx context.Context, code string) (responses.RoomResponse, error) {
+	log := logrus.WithContext(ctx)
+	log.Infof("Getting room %s usecase", code)
+
+	r, err := u.findRoom(code)
+	if err != nil {
+		log.WithError(err).Error("Room not found")
+		return responses.RoomResponse{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.snapshot(), nil
+}
+
+func (u *RoomUseCase) Join(ctx context.Context, code string, userID uint, player Player) error {
+	log := logrus.WithContext(ctx)
+	log.Infof("User ID %d joining room %s", userID, code)
+
+	r, err := u.findRoom(code)
+	if err != nil {
+		log.WithError(err).Error("Room not found")
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.status == RoomStatusFinished {
+		return ErrRoomFinished
+	}
+
+	if userID == r.hostID {
+		r.hostConn = player
+	} else {
+		member, ok := r.players[userID]
+		if !ok {
+			log.Errorf("User ID %d is not assigned to trivia ID %d", userID, r.triviaID)
+			return ErrNotAllowed
+		}
+		if (r.starting || r.status == RoomStatusPlaying) && member.sessionID == 0 {
+			log.Errorf("User ID %d joined after the room started", userID)
+			return ErrRoomStarted
+		}
+		member.conn = player
+	}
+
+	r.broadcast(responses.RoomEvent{Type: EventPlayerJoined, Data: r.snapshot()})
+
+	log.Infof("User ID %d joined room %s", userID, code)
+	return nil
+}
+
+func (u *RoomUseCase) Leave(ctx context.Context, code string, userID uint, player Player) {
+	log := logrus.WithContext(ctx)
+	log.Infof("User ID %d leaving room %s", userID, code)
+
+	r, err := u.findRoom(code)
+	if err != nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if userID == r.hostID && r.hostConn == player {
+		r.hostConn = nil
+	}
+	if member, ok := r.players[userID]; ok && member.conn == player {
+		member.conn = nil
+	}
+	r.broadcast(responses.RoomEvent{Type: EventPlayerLeft, Data: r.snapshot()})
+
+	if r.status == RoomStatusFinished && !r.connected() {
+		u.removeRoom(code)
+	}
+}
+
+func (u *RoomUseCase) HandleMessage(ctx context.Context, code string, userID uint, msg *requests.RoomMessage) error {
+	r, err := u.findRoom(code)
+	if err != nil {
+		return err
+	}
+
+	switch msg.Type {
+	case MessageStart:
+		return u.start(ctx, r, userID)
+	case MessageAnswer:
+		return u.answer(ctx, r, userID, msg)
+	}
+	return ErrUnknownMessage
+}
+
+func (u *RoomUseCase) start(ctx context.Context, r *room, userID uint) error {
+	log := logrus.WithContext(ctx)
+	log.Infof("Starting room %s", r.code)
+
+	r.mu.Lock()
+	if userID != r.hostID {
+		r.mu.Unlock()
+		return ErrNotHost
+	}
+	if r.starting || r.status != RoomStatusWaiting {
+		r.mu.Unlock()
+		return ErrRoomStarted
+	}
+	var players []*roomPlayer
+	var userIDs []uint
+	for _, player := range r.players {
+		if player.conn != nil {
+			players = append(players, player)
+			userIDs = append(userIDs, player.userID)
+		}
+	}
+	if len(players) == 0 {
+		r.mu.Unlock()
+		return ErrNoPlayers
+	}
+	r.starting = true
+	r.mu.Unlock()
+
+	sessions, err := u.gameUseCase.StartSessions(ctx, r.triviaID, userIDs)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.starting = false
+	if err != nil {
+		log.WithError(err).Errorf("Error starting sessions for room %s", r.code)
+		return err
+	}
+
+	r.totalRounds = 0
+	for i, session := range sessions {
+		player := players[i]
+		player.sessionID = session.ID
+		player.score = session.Score
+		player.correctAnswers = session.CorrectAnswers
+		if remaining := session.TotalQuestions - session.AnsweredQuestions; remaining > r.totalRounds {
+			r.totalRounds = remaining
+		}
+	}
+
+	r.status = RoomStatusPlaying
+	r.broadcast(responses.RoomEvent{Type: EventStarted, Data: r.snapshot()})
+	go u.play(r)
+
+	log.Infof("Room %s started", r.code)
+	return nil
+}
+
+func (u *RoomUseCase) play(r *room) {
+	ctx := context.Background()
+	log := logrus.WithContext(ctx)
+
+	for {
+		r.mu.Lock()
+		r.round++
+		round := r.round
+		players := r.playing()
+		r.roundDone = make(chan struct{})
+		done := r.roundDone
+		r.mu.Unlock()
+
+		timeout := r.questionTime
+		served := 0
+		for _, player := range players {
+			question, err := u.gameUseCase.NextQuestion(ctx, r.triviaID, player.sessionID)
+			if err != nil {
+				if !errors.Is(err, gameusecase.ErrSessionFinished) {
+					log.WithError(err).Errorf("Error serving question to user ID %d", player.userID)
+				}
+				r.mu.Lock()
+				player.answeredRound = round
+				r.mu.Unlock()
+				continue
+			}
+
+			served++
+			if limit := time.Duration(question.Question.TimeLimitSeconds) * time.Second; limit > timeout {
+				timeout = limit
+			}
+
+			r.mu.Lock()
+			if player.conn != nil {
+				_ = player.conn.Send(responses.RoomEvent{Type: EventQuestion, Data: responses.RoomQuestionEvent{
+					Round:       round,
+					TotalRounds: r.totalRounds,
+					Question:    question,
+				}})
+			}
+			r.mu.Unlock()
+		}
+		if served == 0 {
+			break
+		}
+
+		r.mu.Lock()
+		r.closeRoundIfDone()
+		r.mu.Unlock()
+
+		select {
+		case <-done:
+		case <-time.After(timeout):
+		}
+
+		r.mu.Lock()
+		r.roundDone = nil
+		var pending []*roomPlayer
+		for _, player := range r.playing() {
+			if player.answeredRound < round {
+				player.answeredRound = round
+				pending = append(pending, player)
+			}
+		}
+		r.mu.Unlock()
+
+		for _, player := range pending {
+			if err := u.gameUseCase.SkipQuestion(ctx, r.triviaID, player.sessionID); err != nil {
+				log.WithError(err).Errorf("Error skipping question for user ID %d", player.userID)
+			}
+		}
+
+		r.mu.Lock()
+		r.broadcast(responses.RoomEvent{Type: EventLeaderboard, Data: r.leaderboard()})
+		r.mu.Unlock()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.status = RoomStatusFinished
+	r.broadcast(responses.RoomEvent{Type: EventFinished, Data: r.leaderboard()})
+	if !r.connected() {
+		u.removeRoom(r.code)
+	}
+	log.Infof("Room %s finished", r.code)
+}
+
+func (u *RoomUseCase) answer(ctx context.Context, r *room, userID uint, msg *requests.RoomMessage) error {
+	log := logrus.WithContext(ctx)
+
+	r.mu.Lock()
+	if r.status != RoomStatusPlaying {
+		r.mu.Unlock()
+		return ErrRoomNotPlaying
+	}
+	player, ok := r.players[userID]
+	if !ok || player.sessionID == 0 {
+		r.mu.Unlock()
+		return ErrNotAllowed
+	}
+	if player.answeredRound >= r.round {
+		r.mu.Unlock()
+		return ErrAlreadyAnswered
+	}
+	round := r.round
+	player.answeredRound = round
+	r.mu.Unlock()
+
+	result, err := u.gameUseCase.AnswerQuestion(ctx, r.triviaID, player.sessionID, &requests.SessionAnswerRequest{
+		QuestionID:      msg.QuestionID,
+		SelectedOption:  msg.SelectedOption,
+		SelectedOptions: msg.SelectedOptions,
+		NumericAnswer:   msg.NumericAnswer,
+		TextAnswer:      msg.TextAnswer,
+	})
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err != nil {
+		log.WithError(err).Errorf("Error answering for user ID %d", userID)
+		// Late and concurrent answers have already closed the question.
+		if errors.Is(err, gameusecase.ErrAnswerTooLate) || errors.Is(err, gameusecase.ErrAlreadyAnswered) {
+			r.closeRoundIfDone()
+			return err
+		}
+		switch {
+		case r.round != round:
+			// The next round already served the question again, so it stays
+			// open for the player to answer there.
+		case r.roundDone != nil:
+			player.answeredRound = round - 1
+		default:
+			// The round closed without skipping the question, as the
+			// player had answered it, and the next one cannot start while
+			// the room is locked.
+			if skipErr := u.gameUseCase.SkipQuestion(ctx, r.triviaID, player.sessionID); skipErr != nil {
+				log.WithError(skipErr).Errorf("Error skipping question for user ID %d", userID)
+			}
+		}
+		return err
+	}
+
+	player.score = result.Session.Score
+	player.correctAnswers = result.Session.CorrectAnswers
+	if player.conn != nil {
+		_ = player.conn.Send(responses.RoomEvent{Type: EventAnswerResult, Data: result})
+	}
+	r.closeRoundIfDone()
+	return nil
+}
+
+func (u *RoomUseCase) findRoom(code string) (*room, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	r, ok := u.rooms[code]
+	if !ok {
+		return nil, ErrRoomNotFound
+	}
+	return r, nil
+}
+
+func (u *RoomUseCase) removeRoom(code string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	delete(u.rooms, code)
+}
+
+func newRoomCode() (string, error) {
+	buf := make([]byte, roomCodeLength)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	for i, b := range buf {
+		buf[i] = roomCodeAlphabet[int(b)%len(roomCodeAlphabet)]
+	}
+	return string(buf), nil
+}
@@ -0,0 +1,15 @@
+package roomusecase
+
+import (
+	"context"
+	"talana_prueba_tecnica/src/entity/requests"
+	"talana_prueba_tecnica/src/entity/responses"
+)
+
+type RoomUseCaseInterface interface {
+	CreateRoom(ctx context.Context, req *requests.CreateRoomRequest) (responses.RoomResponse, error)
+	GetRoom(ctx context.Context, code string) (responses.RoomResponse, error)
+	Join(ctx context.Context, code string, userID uint, player Player) error
+	Leave(ctx context.Context, code string, userID uint, player Player)
+	HandleMessage(ctx context.Context, code string, userID uint, msg *requests.RoomMessage) error
+}
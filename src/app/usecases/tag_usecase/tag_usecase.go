@@ -0,0 +1,124 @@
+package tagusecase
+
+import (
+	"context"
+	"errors"
+	"talana_prueba_tecnica/src/entity/models"
+	"talana_prueba_tecnica/src/entity/requests"
+	"talana_prueba_tecnica/src/entity/responses"
+	tagrepository "talana_prueba_tecnica/src/infraestructure/repository/tag_repository"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrTagNotFound = errors.New("tag not found")
+	ErrInvalidTag  = errors.New("tag requires a name of at most 50 characters")
+)
+
+type TagUseCase struct {
+	repository tagrepository.TagRepositoryInterface
+}
+
+func NewTagUseCase(repository tagrepository.TagRepositoryInterface) *TagUseCase {
+	return &TagUseCase{repository: repository}
+}
+
+func (u *TagUseCase) FindAll(ctx context.Context) ([]responses.TagResponse, error) {
+	log := logrus.WithContext(ctx)
+	log.Info("Get all tags usecase")
+
+	tags, err := u.repository.FindAll(ctx)
+	if err != nil {
+		log.WithError(err).Error("Error finding tags")
+		return nil, err
+	}
+
+	var result []responses.TagResponse
+	for _, tag := range tags {
+		result = append(result, toTagResponse(&tag))
+	}
+
+	log.Info("Tags found")
+	return result, nil
+}
+
+func (u *TagUseCase) CreateTag(ctx context.Context, req *requests.CreateTagRequest) (responses.TagResponse, error) {
+	log := logrus.WithContext(ctx)
+	log.Info("Create tag usecase")
+
+	name, err := tagName(req.Name)
+	if err != nil {
+		log.WithError(err).Error("Invalid tag")
+		return responses.TagResponse{}, err
+	}
+
+	tags, err := u.repository.FindOrCreate(ctx, []string{name})
+	if err != nil {
+		log.WithError(err).Error("Error creating tag")
+		return responses.TagResponse{}, err
+	}
+
+	log.Info("Tag created")
+	return toTagResponse(&tags[0]), nil
+}
+
+func (u *TagUseCase) UpdateTag(ctx context.Context, id uint, req *requests.CreateTagRequest) (responses.TagResponse, error) {
+	log := logrus.WithContext(ctx)
+	log.Infof("Update tag ID %d usecase", id)
+
+	name, err := tagName(req.Name)
+	if err != nil {
+		log.WithError(err).Error("Invalid tag")
+		return responses.TagResponse{}, err
+	}
+
+	tag, err := u.repository.FindByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return responses.TagResponse{}, ErrTagNotFound
+	}
+	if err != nil {
+		return responses.TagResponse{}, err
+	}
+
+	tag.Name = name
+	if err := u.repository.UpdateTag(ctx, tag); err != nil {
+		log.WithError(err).Error("Error updating tag")
+		return responses.TagResponse{}, err
+	}
+
+	log.Info("Tag updated")
+	return toTagResponse(tag), nil
+}
+
+func (u *TagUseCase) DeleteTag(ctx context.Context, id uint) error {
+	log := logrus.WithContext(ctx)
+	log.Infof("Delete tag ID %d usecase", id)
+
+	if _, err := u.repository.FindByID(ctx, id); errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrTagNotFound
+	} else if err != nil {
+		return err
+	}
+
+	if err := u.repository.DeleteTag(ctx, id); err != nil {
+		log.WithError(err).Error("Error deleting tag")
+		return err
+	}
+
+	log.Info("Tag deleted")
+	return nil
+}
+
+func tagName(name string) (string, error) {
+	name = models.NormalizeTagName(name)
+	if name == "" || len(name) > models.MaxTagNameLength {
+		return "", ErrInvalidTag
+	}
+	return name, nil
+}
+
+func toTagResponse(tag *models.Tag) responses.TagResponse {
+	return responses.TagResponse{ID: tag.ID, Name: tag.Name}
+}
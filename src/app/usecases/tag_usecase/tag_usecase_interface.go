@@ -0,0 +1,14 @@
+package tagusecase
+
+import (
+	"context"
+	"talana_prueba_tecnica/src/entity/requests"
+	"talana_prueba_tecnica/src/entity/responses"
+)
+
+type TagUseCaseInterface interface {
+	FindAll(ctx context.Context) ([]responses.TagResponse, error)
+	CreateTag(ctx context.Context, req *requests.CreateTagRequest) (responses.TagResponse, error)
+	UpdateTag(ctx context.Context, id uint, req *requests.CreateTagRequest) (responses.TagResponse, error)
+	DeleteTag(ctx context.Context, id uint) error
+}
@@ -0,0 +1,133 @@
+package triviausecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"talana_prueba_tecnica/src/entity/requests"
+	"talana_prueba_tecnica/src/entity/responses"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+var ErrInvalidBundle = errors.New("invalid bundle")
+
+// QuestionBundler converts questions from and to their bundle form.
+type QuestionBundler interface {
+	BundleQuestions(ctx context.Context, ids []uint) ([]responses.BundleQuestion, error)
+	ImportBundleQuestions(ctx context.Context, questions []responses.BundleQuestion) (map[uint]uint, error)
+}
+
+// ExportTrivia returns the trivia and its questions as a bundle.
+func (u *TriviaUseCase) ExportTrivia(ctx context.Context, id uint) (responses.ContentBundle, error) {
+	log := logrus.WithContext(ctx)
+	log.Infof("Exporting trivia ID %d usecase", id)
+
+	trivia, err := u.triviaRepository.FindByID(ctx, id)
+	if err != nil {
+		log.WithError(err).Error("Trivia not found")
+		return responses.ContentBundle{}, ErrTriviaNotFound
+	}
+
+	refs := make([]uint, 0, len(trivia.Questions))
+	for _, question := range trivia.Questions {
+		refs = append(refs, question.ID)
+	}
+	sort.Slice(refs, func(i, j int) bool { return refs[i] < refs[j] })
+
+	questions, err := u.bundler.BundleQuestions(ctx, refs)
+	if err != nil {
+		log.WithError(err).Error("Error exporting trivia questions")
+		return responses.ContentBundle{}, err
+	}
+
+	log.Info("Trivia exported successfully")
+	return responses.ContentBundle{
+		Version:    responses.BundleVersion,
+		ExportedAt: time.Now().UTC(),
+		Questions:  questions,
+		Trivia: &responses.BundleTrivia{
+			Name:              trivia.Name,
+			Description:       trivia.Description,
+			ScoringStrategy:   trivia.ScoringStrategy,
+			TimeLimitSeconds:  trivia.TimeLimitSeconds,
+			MaxAttempts:       trivia.MaxAttempts,
+			CooldownSeconds:   trivia.CooldownSeconds,
+			RankingPolicy:     trivia.RankingPolicy,
+			RequireAllAnswers: trivia.RequireAllAnswers,
+			ShuffleQuestions:  trivia.ShuffleQuestions,
+			ShuffleOptions:    trivia.ShuffleOptions,
+			QuestionPoolSize:  trivia.QuestionPoolSize,
+			QuestionRefs:      refs,
+		},
+	}, nil
+}
+
+// ImportTrivia creates the questions of bundle and a trivia using them,
+// mapping the question refs of the bundle to the new question IDs. Nothing
+// is saved if any part is invalid.
+func (u *TriviaUseCase) ImportTrivia(ctx context.Context, bundle *responses.ContentBundle) (responses.AuthorTriviaResponse, error) {
+	log := logrus.WithContext(ctx)
+	log.Info("Importing trivia usecase")
+
+	if bundle.Version != responses.BundleVersion {
+		log.Errorf("Unsupported bundle version %d", bundle.Version)
+		return responses.AuthorTriviaResponse{}, fmt.Errorf("%w: unsupported bundle version %d", ErrInvalidBundle, bundle.Version)
+	}
+	if bundle.Trivia == nil {
+		log.Error("Bundle without trivia")
+		return responses.AuthorTriviaResponse{}, fmt.Errorf("%w: bundle has no trivia", ErrInvalidBundle)
+	}
+	if len(bundle.Trivia.QuestionRefs) == 0 {
+		log.Error("Bundle trivia without questions")
+		return responses.AuthorTriviaResponse{}, fmt.Errorf("%w: trivia has no questions", ErrInvalidBundle)
+	}
+
+	var result responses.AuthorTriviaResponse
+	err := u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		ids, err := u.bundler.ImportBundleQuestions(ctx, bundle.Questions)
+		if err != nil {
+			return err
+		}
+
+		settings := bundle.Trivia
+		questionIDs := make([]uint, 0, len(settings.QuestionRefs))
+		for _, ref := range settings.QuestionRefs {
+			id, ok := ids[ref]
+			if !ok {
+				return fmt.Errorf("%w: unknown question ref %d", ErrInvalidBundle, ref)
+			}
+			questionIDs = append(questionIDs, id)
+		}
+
+		trivia, err := u.createTrivia(ctx, &requests.CreateTriviaRequest{
+			Name:              settings.Name,
+			Description:       settings.Description,
+			ScoringStrategy:   settings.ScoringStrategy,
+			TimeLimitSeconds:  settings.TimeLimitSeconds,
+			MaxAttempts:       settings.MaxAttempts,
+			CooldownSeconds:   settings.CooldownSeconds,
+			RankingPolicy:     settings.RankingPolicy,
+			RequireAllAnswers: settings.RequireAllAnswers,
+			ShuffleQuestions:  settings.ShuffleQuestions,
+			ShuffleOptions:    settings.ShuffleOptions,
+			QuestionPoolSize:  settings.QuestionPoolSize,
+			QuestionIDs:       questionIDs,
+			UserIDs:           settings.UserIDs,
+		})
+		if err != nil {
+			return err
+		}
+		result = toAuthorTriviaResponse(*trivia)
+		return nil
+	})
+	if err != nil {
+		log.WithError(err).Error("Error importing trivia")
+		return responses.AuthorTriviaResponse{}, err
+	}
+
+	log.Infof("Trivia imported with ID %d", result.ID)
+	return result, nil
+}
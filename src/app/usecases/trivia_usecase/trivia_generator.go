@@ -0,0 +1,211 @@
+package triviausecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"talana_prueba_tecnica/src/entity/models"
+	"talana_prueba_tecnica/src/entity/requests"
+	"talana_prueba_tecnica/src/entity/responses"
+	questionsrepository "talana_prueba_tecnica/src/infraestructure/repository/questions_repository"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrInvalidGeneration  = errors.New("invalid generation rules")
+	ErrNotEnoughQuestions = errors.New("not enough questions in the bank")
+)
+
+const maxGeneratedQuestions = 100
+
+// difficulties is the order quotas are computed and filled in.
+var difficulties = []string{"facil", "medio", "dificil"}
+
+type difficultyQuota struct {
+	difficulty string
+	count      int
+}
+
+// GenerateTrivia picks the questions of a new trivia from the bank following
+// the rules in req and creates it like CreateTrivia.
+func (u *TriviaUseCase) GenerateTrivia(ctx context.Context, req *requests.GenerateTriviaRequest) (responses.AuthorTriviaResponse, error) {
+	log := logrus.WithContext(ctx)
+	log.Info("Generating trivia usecase")
+
+	if req.QuestionCount < 1 || req.QuestionCount > maxGeneratedQuestions {
+		log.Errorf("Invalid question count %d", req.QuestionCount)
+		return responses.AuthorTriviaResponse{}, fmt.Errorf("%w: question count must be between 1 and %d", ErrInvalidGeneration, maxGeneratedQuestions)
+	}
+
+	quotas, err := difficultyQuotas(req.QuestionCount, req.DifficultyMix)
+	if err != nil {
+		log.WithError(err).Error("Invalid difficulty mix")
+		return responses.AuthorTriviaResponse{}, err
+	}
+
+	var result responses.AuthorTriviaResponse
+	err = u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		questionIDs, err := u.pickQuestions(ctx, req, quotas)
+		if err != nil {
+			return err
+		}
+
+		trivia, err := u.createTrivia(ctx, &requests.CreateTriviaRequest{
+			Name:              req.Name,
+			Description:       req.Description,
+			ScoringStrategy:   req.ScoringStrategy,
+			TimeLimitSeconds:  req.TimeLimitSeconds,
+			MaxAttempts:       req.MaxAttempts,
+			CooldownSeconds:   req.CooldownSeconds,
+			RankingPolicy:     req.RankingPolicy,
+			RequireAllAnswers: req.RequireAllAnswers,
+			ShuffleQuestions:  req.ShuffleQuestions,
+			ShuffleOptions:    req.ShuffleOptions,
+			QuestionPoolSize:  req.QuestionPoolSize,
+			QuestionIDs:       questionIDs,
+			UserIDs:           req.UserIDs,
+		})
+		if err != nil {
+			return err
+		}
+		result = toAuthorTriviaResponse(*trivia)
+		return nil
+	})
+	if err != nil {
+		log.WithError(err).Error("Error generating trivia")
+		return responses.AuthorTriviaResponse{}, err
+	}
+
+	log.Info("Trivia generated successfully")
+	return result, nil
+}
+
+// pickQuestions draws the questions of every quota at random among the
+// matching ones.
+func (u *TriviaUseCase) pickQuestions(ctx context.Context, req *requests.GenerateTriviaRequest, quotas []difficultyQuota) ([]uint, error) {
+	seen := map[uint]bool{}
+	if req.ExcludeSeen && len(req.UserIDs) > 0 {
+		answered, err := u.triviaRepository.FindAnsweredQuestionIDs(ctx, req.UserIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range answered {
+			seen[id] = true
+		}
+	}
+
+	var picked []uint
+	for _, quota := range quotas {
+		if quota.count == 0 {
+			continue
+		}
+		candidates, err := u.candidateQuestions(ctx, req.CategoryIDs, quota.difficulty, seen)
+		if err != nil {
+			return nil, err
+		}
+		if len(candidates) < quota.count {
+			label := quota.difficulty
+			if label == "" {
+				label = "matching"
+			}
+			return nil, fmt.Errorf("%w: %d %s questions requested, %d available", ErrNotEnoughQuestions, quota.count, label, len(candidates))
+		}
+
+		rand.Shuffle(len(candidates), func(i, j int) {
+			candidates[i], candidates[j] = candidates[j], candidates[i]
+		})
+		picked = append(picked, candidates[:quota.count]...)
+	}
+	return picked, nil
+}
+
+// candidateQuestions returns the IDs of the published questions of
+// difficulty in any of categoryIDs that are not in seen. An empty
+// categoryIDs matches every category and an empty difficulty every
+// difficulty.
+func (u *TriviaUseCase) candidateQuestions(ctx context.Context, categoryIDs []uint, difficulty string, seen map[uint]bool) ([]uint, error) {
+	filters := []questionsrepository.QuestionFilter{{Difficulty: difficulty, Status: models.StatusPublished}}
+	if len(categoryIDs) > 0 {
+		filters = nil
+		for _, categoryID := range categoryIDs {
+			filters = append(filters, questionsrepository.QuestionFilter{CategoryID: categoryID, Difficulty: difficulty, Status: models.StatusPublished})
+		}
+	}
+
+	found := map[uint]bool{}
+	var candidates []uint
+	for _, filter := range filters {
+		questions, err := u.questionRepo.FindAll(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		for _, question := range questions {
+			if seen[question.ID] || found[question.ID] {
+				continue
+			}
+			found[question.ID] = true
+			candidates = append(candidates, question.ID)
+		}
+	}
+	return candidates, nil
+}
+
+// difficultyQuotas splits count among the difficulties of mix, giving the
+// questions lost to rounding to the largest remainders.
+func difficultyQuotas(count int, mix map[string]int) ([]difficultyQuota, error) {
+	if len(mix) == 0 {
+		return []difficultyQuota{{count: count}}, nil
+	}
+
+	total := 0
+	for difficulty, percent := range mix {
+		if !isDifficulty(difficulty) {
+			return nil, fmt.Errorf("%w: unknown difficulty %q", ErrInvalidGeneration, difficulty)
+		}
+		if percent < 0 {
+			return nil, fmt.Errorf("%w: percentage of %s cannot be negative", ErrInvalidGeneration, difficulty)
+		}
+		total += percent
+	}
+	if total != 100 {
+		return nil, fmt.Errorf("%w: difficulty mix must add up to 100, got %d", ErrInvalidGeneration, total)
+	}
+
+	var quotas []difficultyQuota
+	remainders := map[string]int{}
+	assigned := 0
+	for _, difficulty := range difficulties {
+		percent, ok := mix[difficulty]
+		if !ok {
+			continue
+		}
+		quotas = append(quotas, difficultyQuota{difficulty: difficulty, count: count * percent / 100})
+		remainders[difficulty] = count * percent % 100
+		assigned += count * percent / 100
+	}
+
+	order := make([]int, len(quotas))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return remainders[quotas[order[a]].difficulty] > remainders[quotas[order[b]].difficulty]
+	})
+	for i := 0; assigned < count; i++ {
+		quotas[order[i]].count++
+		assigned++
+	}
+	return quotas, nil
+}
+
+func isDifficulty(difficulty string) bool {
+	for _, known := range difficulties {
+		if difficulty == known {
+			return true
+		}
+	}
+	return false
+}
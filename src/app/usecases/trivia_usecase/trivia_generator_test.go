@@ -0,0 +1,82 @@
+package triviausecase
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestDifficultyQuotas(t *testing.T) {
+	tests := []struct {
+		name    string
+		count   int
+		mix     map[string]int
+		want    []difficultyQuota
+		wantErr bool
+	}{
+		{
+			name:  "no mix",
+			count: 7,
+			want:  []difficultyQuota{{count: 7}},
+		},
+		{
+			name:  "exact split",
+			count: 10,
+			mix:   map[string]int{"facil": 50, "medio": 30, "dificil": 20},
+			want:  []difficultyQuota{{"facil", 5}, {"medio", 3}, {"dificil", 2}},
+		},
+		{
+			name:  "rounding goes to the largest remainders",
+			count: 7,
+			mix:   map[string]int{"facil": 50, "medio": 30, "dificil": 20},
+			want:  []difficultyQuota{{"facil", 4}, {"medio", 2}, {"dificil", 1}},
+		},
+		{
+			name:  "ties keep the difficulty order",
+			count: 2,
+			mix:   map[string]int{"facil": 34, "medio": 33, "dificil": 33},
+			want:  []difficultyQuota{{"facil", 1}, {"medio", 1}, {"dificil", 0}},
+		},
+		{
+			name:  "single difficulty",
+			count: 3,
+			mix:   map[string]int{"dificil": 100},
+			want:  []difficultyQuota{{"dificil", 3}},
+		},
+		{
+			name:    "mix not adding up to 100",
+			count:   5,
+			mix:     map[string]int{"facil": 50, "medio": 40},
+			wantErr: true,
+		},
+		{
+			name:    "negative percentage",
+			count:   5,
+			mix:     map[string]int{"facil": 110, "medio": -10},
+			wantErr: true,
+		},
+		{
+			name:    "unknown difficulty",
+			count:   5,
+			mix:     map[string]int{"facil": 50, "experto": 50},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := difficultyQuotas(tt.count, tt.mix)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidGeneration) {
+					t.Fatalf("difficultyQuotas error %v, want %v", err, ErrInvalidGeneration)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("difficultyQuotas: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("difficultyQuotas = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
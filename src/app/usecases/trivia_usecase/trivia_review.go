@@ -0,0 +1,73 @@
+package triviausecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"talana_prueba_tecnica/src/entity/models"
+	"talana_prueba_tecnica/src/entity/requests"
+	"talana_prueba_tecnica/src/entity/responses"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+var ErrUnpublishedQuestions = errors.New("trivia has questions that are not published")
+
+// ChangeTriviaStatus moves the trivia through the editorial workflow. A
+// trivia can only be sent to review or published once all its questions
+// are published.
+func (u *TriviaUseCase) ChangeTriviaStatus(ctx context.Context, id uint, req *requests.StatusChangeRequest) (responses.AuthorTriviaResponse, error) {
+	log := logrus.WithContext(ctx)
+	log.Infof("Changing status of trivia ID %d usecase", id)
+
+	var trivia models.Trivia
+	err := u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		var err error
+		trivia, err = u.triviaRepository.FindByID(ctx, id)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrTriviaNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		if req.Status == models.StatusInReview || req.Status == models.StatusPublished {
+			if len(trivia.Questions) == 0 {
+				return fmt.Errorf("%w: trivia has no questions", ErrUnpublishedQuestions)
+			}
+			for _, question := range trivia.Questions {
+				if question.Status != models.StatusPublished {
+					return fmt.Errorf("%w: question ID %d is %s", ErrUnpublishedQuestions, question.ID, question.Status)
+				}
+			}
+		}
+
+		if err := u.reviews.Transition(ctx, models.ContentTrivia, id, trivia.Status, req); err != nil {
+			return err
+		}
+		if err := u.triviaRepository.UpdateStatus(ctx, id, req.Status); err != nil {
+			return err
+		}
+		trivia.Status = req.Status
+		return nil
+	})
+	if err != nil {
+		log.WithError(err).Error("Error changing trivia status")
+		return responses.AuthorTriviaResponse{}, err
+	}
+
+	log.Infof("Trivia ID %d is now %s", id, trivia.Status)
+	return toAuthorTriviaResponse(trivia), nil
+}
+
+// TriviaReviews returns the status changes of the trivia, oldest first.
+func (u *TriviaUseCase) TriviaReviews(ctx context.Context, id uint) ([]responses.ReviewEventResponse, error) {
+	log := logrus.WithContext(ctx)
+
+	if _, err := u.triviaRepository.FindByID(ctx, id); err != nil {
+		log.WithError(err).Error("Trivia not found")
+		return nil, ErrTriviaNotFound
+	}
+	return u.reviews.History(ctx, models.ContentTrivia, id)
+}
@@ -3,31 +3,53 @@ package triviausecase
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	reviewusecase "talana_prueba_tecnica/src/app/usecases/review_usecase"
 	"talana_prueba_tecnica/src/entity/models"
 	"talana_prueba_tecnica/src/entity/requests"
 	"talana_prueba_tecnica/src/entity/responses"
 	questionsrepository "talana_prueba_tecnica/src/infraestructure/repository/questions_repository"
 	triviarepository "talana_prueba_tecnica/src/infraestructure/repository/trivia_repository"
 	repository "talana_prueba_tecnica/src/infraestructure/repository/user_repository"
+	"talana_prueba_tecnica/src/shared"
 
 	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrTriviaNotFound        = errors.New("trivia not found")
+	ErrUserNotFound          = errors.New("user not found")
+	ErrParticipationNotFound = errors.New("participation not found")
+	ErrReviewNotAvailable    = errors.New("answers are only available after completing the trivia with no attempts left")
 )
 
 type TriviaUseCase struct {
 	triviaRepository triviarepository.TriviaRepositoryInterface
 	userRepository   repository.UserRepositoryInterface
 	questionRepo     questionsrepository.QuestionRepositoryInterface
+	bundler          QuestionBundler
+	reviews          reviewusecase.ReviewUseCaseInterface
+	unitOfWork       shared.UnitOfWork
 }
 
 func NewTriviaUseCase(
 	triviaRepository triviarepository.TriviaRepositoryInterface,
 	userRepository repository.UserRepositoryInterface,
 	questionRepo questionsrepository.QuestionRepositoryInterface,
+	bundler QuestionBundler,
+	reviews reviewusecase.ReviewUseCaseInterface,
+	unitOfWork shared.UnitOfWork,
 ) *TriviaUseCase {
 	return &TriviaUseCase{
 		triviaRepository: triviaRepository,
 		userRepository:   userRepository,
 		questionRepo:     questionRepo,
+		bundler:          bundler,
+		reviews:          reviews,
+		unitOfWork:       unitOfWork,
 	}
 }
 
@@ -35,45 +57,98 @@ func (u *TriviaUseCase) CreateTrivia(ctx context.Context, req *requests.CreateTr
 	log := logrus.WithContext(ctx)
 	log.Info("Creating trivia usecase")
 
-	trivia := &models.Trivia{
-		Name:        req.Name,
-		Description: req.Description,
+	if _, err := u.createTrivia(ctx, req); err != nil {
+		return err
 	}
 
-	var questions []models.Question
-	for _, questionID := range req.QuestionIDs {
-		question, err := u.questionRepo.FindByID(ctx, questionID)
-		if err != nil {
-			log.WithError(err).Errorf("Question ID %d not found", questionID)
-			return errors.New("invalid question ID")
-		}
-		// Solo añadir la pregunta, no modificarla
-		questions = append(questions, *question)
+	log.Info("Trivia created successfully")
+	return nil
+}
+
+func (u *TriviaUseCase) createTrivia(ctx context.Context, req *requests.CreateTriviaRequest) (*models.Trivia, error) {
+	log := logrus.WithContext(ctx)
+
+	if err := validateScoringStrategy(req.ScoringStrategy); err != nil {
+		log.WithError(err).Error("Invalid scoring strategy")
+		return nil, err
 	}
-	trivia.Questions = questions
 
-	var users []models.UserModel
-	for _, userID := range req.UserIDs {
-		user, err := u.userRepository.FindByID(ctx, userID)
-		if err != nil {
-			log.WithError(err).Errorf("User ID %d not found", userID)
-			return errors.New("invalid user ID")
-		}
-		users = append(users, *user)
+	if req.TimeLimitSeconds < 0 {
+		log.Error("Time limit cannot be negative")
+		return nil, errors.New("time limit cannot be negative")
 	}
-	trivia.Users = users
 
-	err := u.triviaRepository.CreateTrivia(ctx, trivia)
-	if err != nil {
-		log.WithError(err).Error("Error creating trivia in repository")
-		return err
+	if err := validateAttemptPolicy(req); err != nil {
+		log.WithError(err).Error("Invalid attempt policy")
+		return nil, err
 	}
 
-	log.Info("Trivia created successfully")
-	return nil
+	if err := validateQuestionPool(req); err != nil {
+		log.WithError(err).Error("Invalid question pool size")
+		return nil, err
+	}
+
+	trivia := &models.Trivia{
+		Name:              req.Name,
+		Description:       req.Description,
+		ScoringStrategy:   req.ScoringStrategy,
+		TimeLimitSeconds:  req.TimeLimitSeconds,
+		MaxAttempts:       req.MaxAttempts,
+		CooldownSeconds:   req.CooldownSeconds,
+		RankingPolicy:     req.RankingPolicy,
+		RequireAllAnswers: req.RequireAllAnswers,
+		ShuffleQuestions:  req.ShuffleQuestions,
+		ShuffleOptions:    req.ShuffleOptions,
+		QuestionPoolSize:  req.QuestionPoolSize,
+		Status:            models.StatusDraft,
+	}
+	if trivia.ScoringStrategy == "" {
+		trivia.ScoringStrategy = models.DefaultScoringStrategy
+	}
+	if trivia.RankingPolicy == "" {
+		trivia.RankingPolicy = models.DefaultRankingPolicy
+	}
+
+	err := u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		var questions []models.Question
+		for _, questionID := range req.QuestionIDs {
+			question, err := u.questionRepo.FindByID(ctx, questionID)
+			if err != nil {
+				log.WithError(err).Errorf("Question ID %d not found", questionID)
+				return errors.New("invalid question ID")
+			}
+			// Solo añadir la pregunta, no modificarla
+			questions = append(questions, *question)
+		}
+		trivia.Questions = questions
+
+		var users []models.UserModel
+		for _, userID := range req.UserIDs {
+			user, err := u.userRepository.FindByID(ctx, userID)
+			if err != nil {
+				log.WithError(err).Errorf("User ID %d not found", userID)
+				return errors.New("invalid user ID")
+			}
+			users = append(users, *user)
+		}
+		trivia.Users = users
+
+		if err := u.triviaRepository.CreateTrivia(ctx, trivia); err != nil {
+			log.WithError(err).Error("Error creating trivia in repository")
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return trivia, nil
 }
 
-func (u *TriviaUseCase) FindAll(ctx context.Context) ([]responses.TriviaResponse, error) {
+// FindAll returns every trivia; with publishedOnly, only the published ones
+// and without their questions, which players get one at a time through game
+// sessions.
+func (u *TriviaUseCase) FindAll(ctx context.Context, publishedOnly bool) ([]responses.TriviaResponse, error) {
 	log := logrus.WithContext(ctx)
 	log.Info("Finding all trivias usecase")
 
@@ -85,39 +160,60 @@ func (u *TriviaUseCase) FindAll(ctx context.Context) ([]responses.TriviaResponse
 
 	var triviaResponses []responses.TriviaResponse
 	for _, trivia := range trivias {
+		if publishedOnly {
+			if trivia.Status != models.StatusPublished {
+				continue
+			}
+			trivia.Questions = nil
+		}
+
 		var questionResponses []responses.QuestionResponse
 		for _, question := range trivia.Questions {
 			var optionResponses []responses.OptionResponse
-			for _, option := range question.Options {
+			for i, option := range question.PlayerOptions() {
 				optionResponses = append(optionResponses, responses.OptionResponse{
-					ID:     option.ID,
+					Index:  i,
 					Option: option.Text,
 				})
 			}
 
 			questionResponses = append(questionResponses, responses.QuestionResponse{
-				ID:         question.ID,
-				Question:   question.Question,
-				Options:    optionResponses,
-				Difficulty: question.Difficulty,
+				ID:               question.ID,
+				Question:         question.Question,
+				Type:             question.Type,
+				Options:          optionResponses,
+				Difficulty:       question.Difficulty,
+				TimeLimitSeconds: question.TimeLimitSeconds,
+				Status:           question.Status,
 			})
 		}
 
 		var userResponses []responses.UserResponse
 		for _, user := range trivia.Users {
 			userResponses = append(userResponses, responses.UserResponse{
-				ID:    user.ID,
-				Name:  user.Name,
-				Email: user.Email,
+				ID:         user.ID,
+				Name:       user.Name,
+				Email:      user.Email,
+				Department: user.Department,
 			})
 		}
 
 		triviaResponses = append(triviaResponses, responses.TriviaResponse{
-			ID:          trivia.ID,
-			Name:        trivia.Name,
-			Description: trivia.Description,
-			Questions:   questionResponses,
-			Users:       userResponses,
+			ID:                trivia.ID,
+			Name:              trivia.Name,
+			Description:       trivia.Description,
+			ScoringStrategy:   trivia.ScoringStrategy,
+			TimeLimitSeconds:  trivia.TimeLimitSeconds,
+			MaxAttempts:       trivia.MaxAttempts,
+			CooldownSeconds:   trivia.CooldownSeconds,
+			RankingPolicy:     trivia.RankingPolicy,
+			RequireAllAnswers: trivia.RequireAllAnswers,
+			ShuffleQuestions:  trivia.ShuffleQuestions,
+			ShuffleOptions:    trivia.ShuffleOptions,
+			QuestionPoolSize:  trivia.QuestionPoolSize,
+			Status:            trivia.Status,
+			Questions:         questionResponses,
+			Users:             userResponses,
 		})
 	}
 
@@ -125,7 +221,9 @@ func (u *TriviaUseCase) FindAll(ctx context.Context) ([]responses.TriviaResponse
 	return triviaResponses, nil
 }
 
-func (u *TriviaUseCase) FindByID(ctx context.Context, id uint) (responses.TriviaResponse, error) {
+// FindByID returns the trivia with id; with publishedOnly, unpublished
+// trivias are reported as not found and questions left out.
+func (u *TriviaUseCase) FindByID(ctx context.Context, id uint, publishedOnly bool) (responses.TriviaResponse, error) {
 	log := logrus.WithContext(ctx)
 	log.Infof("Finding trivia by ID: %d usecase", id)
 
@@ -134,54 +232,194 @@ func (u *TriviaUseCase) FindByID(ctx context.Context, id uint) (responses.Trivia
 		log.WithError(err).Error("Error finding trivia by ID in repository")
 		return responses.TriviaResponse{}, err
 	}
+	if publishedOnly {
+		if trivia.Status != models.StatusPublished {
+			log.Errorf("Trivia ID %d is not published", id)
+			return responses.TriviaResponse{}, ErrTriviaNotFound
+		}
+		trivia.Questions = nil
+	}
 
 	var questionResponses []responses.QuestionResponse
 	for _, question := range trivia.Questions {
 		var optionResponses []responses.OptionResponse
-		for _, option := range question.Options {
+		for i, option := range question.PlayerOptions() {
 			optionResponses = append(optionResponses, responses.OptionResponse{
-				ID:     option.ID,
+				Index:  i,
 				Option: option.Text,
 			})
 		}
 
 		questionResponses = append(questionResponses, responses.QuestionResponse{
-			ID:            question.ID,
-			Question:      question.Question,
-			CorrectOption: question.CorrectOption,
-			Options:       optionResponses,
-			Difficulty:    question.Difficulty,
+			ID:               question.ID,
+			Question:         question.Question,
+			Type:             question.Type,
+			Options:          optionResponses,
+			Difficulty:       question.Difficulty,
+			TimeLimitSeconds: question.TimeLimitSeconds,
+			Status:           question.Status,
 		})
 	}
 
 	var userResponses []responses.UserResponse
 	for _, user := range trivia.Users {
 		userResponses = append(userResponses, responses.UserResponse{
-			ID:    user.ID,
-			Name:  user.Name,
-			Email: user.Email,
+			ID:         user.ID,
+			Name:       user.Name,
+			Email:      user.Email,
+			Department: user.Department,
 		})
 	}
 
 	response := responses.TriviaResponse{
-		ID:          trivia.ID,
-		Name:        trivia.Name,
-		Description: trivia.Description,
-		Questions:   questionResponses,
-		Users:       userResponses,
+		ID:                trivia.ID,
+		Name:              trivia.Name,
+		Description:       trivia.Description,
+		ScoringStrategy:   trivia.ScoringStrategy,
+		TimeLimitSeconds:  trivia.TimeLimitSeconds,
+		MaxAttempts:       trivia.MaxAttempts,
+		CooldownSeconds:   trivia.CooldownSeconds,
+		RankingPolicy:     trivia.RankingPolicy,
+		RequireAllAnswers: trivia.RequireAllAnswers,
+		ShuffleQuestions:  trivia.ShuffleQuestions,
+		ShuffleOptions:    trivia.ShuffleOptions,
+		QuestionPoolSize:  trivia.QuestionPoolSize,
+		Status:            trivia.Status,
+		Questions:         questionResponses,
+		Users:             userResponses,
 	}
 
 	log.Info("Trivia found successfully")
 	return response, nil
 }
 
+func (u *TriviaUseCase) FindByIDForAuthor(ctx context.Context, id uint) (responses.AuthorTriviaResponse, error) {
+	log := logrus.WithContext(ctx)
+	log.Infof("Finding trivia by ID: %d for author usecase", id)
+
+	trivia, err := u.triviaRepository.FindByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		log.WithError(err).Error("Trivia not found")
+		return responses.AuthorTriviaResponse{}, ErrTriviaNotFound
+	}
+	if err != nil {
+		log.WithError(err).Error("Error finding trivia by ID in repository")
+		return responses.AuthorTriviaResponse{}, err
+	}
+
+	log.Info("Trivia found successfully")
+	return toAuthorTriviaResponse(trivia), nil
+}
+
+// GetReview returns the trivia with its correct answers once the user has
+// completed it and used all its attempts, so the answers cannot be used to
+// retake it. Trivias with unlimited attempts are never reviewed.
+func (u *TriviaUseCase) GetReview(ctx context.Context, triviaID, userID uint) (responses.AuthorTriviaResponse, error) {
+	log := logrus.WithContext(ctx)
+	log.Infof("Getting review of trivia ID: %d for user ID: %d usecase", triviaID, userID)
+
+	trivia, err := u.triviaRepository.FindByID(ctx, triviaID)
+	if err != nil {
+		log.WithError(err).Error("Error finding trivia for review in repository")
+		return responses.AuthorTriviaResponse{}, ErrTriviaNotFound
+	}
+
+	_, err = u.triviaRepository.GetUserScore(ctx, triviaID, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		log.WithError(err).Error("User has not completed the trivia")
+		return responses.AuthorTriviaResponse{}, ErrReviewNotAvailable
+	}
+	if err != nil {
+		log.WithError(err).Error("Error getting user participation in repository")
+		return responses.AuthorTriviaResponse{}, err
+	}
+
+	revealed, err := u.answersRevealed(ctx, &trivia, userID)
+	if err != nil {
+		log.WithError(err).Error("Error counting attempts in repository")
+		return responses.AuthorTriviaResponse{}, err
+	}
+	if !revealed {
+		log.Error("User has attempts left")
+		return responses.AuthorTriviaResponse{}, ErrReviewNotAvailable
+	}
+
+	log.Info("Trivia review retrieved successfully")
+	return toAuthorTriviaResponse(trivia), nil
+}
+
+// answersRevealed reports whether userID may see the correct answers of
+// trivia: only once every attempt is used, so they cannot be used to retake
+// it. Trivias with unlimited attempts never reveal them.
+func (u *TriviaUseCase) answersRevealed(ctx context.Context, trivia *models.Trivia, userID uint) (bool, error) {
+	if trivia.MaxAttempts == 0 {
+		return false, nil
+	}
+	attempts, err := u.triviaRepository.CountAttempts(ctx, trivia.ID, userID)
+	if err != nil {
+		return false, err
+	}
+	return attempts >= int64(trivia.MaxAttempts), nil
+}
+
 func (u *TriviaUseCase) UpdateTrivia(ctx context.Context, req *requests.CreateTriviaRequest, id uint) error {
 	log := logrus.WithContext(ctx)
 	log.Infof("Updating trivia with ID: %d usecase", id)
 
+	if err := validateScoringStrategy(req.ScoringStrategy); err != nil {
+		log.WithError(err).Error("Invalid scoring strategy")
+		return err
+	}
+
+	if req.TimeLimitSeconds < 0 {
+		log.Error("Time limit cannot be negative")
+		return errors.New("time limit cannot be negative")
+	}
+
+	if err := validateAttemptPolicy(req); err != nil {
+		log.WithError(err).Error("Invalid attempt policy")
+		return err
+	}
+
+	if err := validateQuestionPool(req); err != nil {
+		log.WithError(err).Error("Invalid question pool size")
+		return err
+	}
+
+	current, err := u.triviaRepository.FindByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		log.WithError(err).Error("Trivia not found")
+		return ErrTriviaNotFound
+	}
+	if err != nil {
+		log.WithError(err).Error("Error finding trivia by ID in repository")
+		return err
+	}
+	if err := reviewusecase.EnsureEditable(current.Status); err != nil {
+		log.WithError(err).Errorf("Trivia ID %d is %s", id, current.Status)
+		return err
+	}
+
 	trivia := &models.Trivia{
-		Name:        req.Name,
-		Description: req.Description,
+		Name:              req.Name,
+		Description:       req.Description,
+		ScoringStrategy:   req.ScoringStrategy,
+		TimeLimitSeconds:  req.TimeLimitSeconds,
+		MaxAttempts:       req.MaxAttempts,
+		CooldownSeconds:   req.CooldownSeconds,
+		RankingPolicy:     req.RankingPolicy,
+		RequireAllAnswers: req.RequireAllAnswers,
+		ShuffleQuestions:  req.ShuffleQuestions,
+		ShuffleOptions:    req.ShuffleOptions,
+		QuestionPoolSize:  req.QuestionPoolSize,
+	}
+	// Every column is written, so the policies left out of the request keep
+	// their current value instead of being cleared.
+	if trivia.ScoringStrategy == "" {
+		trivia.ScoringStrategy = current.ScoringStrategy
+	}
+	if trivia.RankingPolicy == "" {
+		trivia.RankingPolicy = current.RankingPolicy
 	}
 
 	for _, questionID := range req.QuestionIDs {
@@ -192,7 +430,7 @@ func (u *TriviaUseCase) UpdateTrivia(ctx context.Context, req *requests.CreateTr
 		trivia.Users = append(trivia.Users, models.UserModel{ID: userID})
 	}
 
-	err := u.triviaRepository.UpdateTrivia(ctx, trivia, id)
+	err = u.triviaRepository.UpdateTrivia(ctx, trivia, id)
 	if err != nil {
 		log.WithError(err).Error("Error updating trivia in repository")
 		return err
@@ -209,7 +447,7 @@ func (u *TriviaUseCase) DeleteTrivia(ctx context.Context, id uint) error {
 	_, err := u.triviaRepository.FindByID(ctx, id)
 	if err != nil {
 		log.WithError(err).Error("Error finding trivia for deletion in repository")
-		return errors.New("trivia not found")
+		return ErrTriviaNotFound
 	}
 
 	err = u.triviaRepository.DeleteTrivia(ctx, id)
@@ -222,6 +460,44 @@ func (u *TriviaUseCase) DeleteTrivia(ctx context.Context, id uint) error {
 	return nil
 }
 
+// RestoreTrivia brings back a deleted trivia.
+func (u *TriviaUseCase) RestoreTrivia(ctx context.Context, id uint) error {
+	log := logrus.WithContext(ctx)
+	log.Infof("Restoring trivia with ID: %d usecase", id)
+
+	err := u.triviaRepository.RestoreTrivia(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Error("Deleted trivia not found")
+		return ErrTriviaNotFound
+	}
+	if err != nil {
+		log.WithError(err).Error("Error restoring trivia in repository")
+		return err
+	}
+
+	log.Info("Trivia restored successfully")
+	return nil
+}
+
+// PurgeTrivia permanently removes a deleted trivia and its participations.
+func (u *TriviaUseCase) PurgeTrivia(ctx context.Context, id uint) error {
+	log := logrus.WithContext(ctx)
+	log.Infof("Purging trivia with ID: %d usecase", id)
+
+	err := u.triviaRepository.PurgeTrivia(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Error("Deleted trivia not found")
+		return ErrTriviaNotFound
+	}
+	if err != nil {
+		log.WithError(err).Error("Error purging trivia in repository")
+		return err
+	}
+
+	log.Info("Trivia purged successfully")
+	return nil
+}
+
 func (u *TriviaUseCase) AssignUserToTrivia(ctx context.Context, TriviaID, UserID uint) error {
 	log := logrus.WithContext(ctx)
 	log.Infof("Assigning user ID: %d to trivia ID: %d usecase", UserID, TriviaID)
@@ -229,13 +505,13 @@ func (u *TriviaUseCase) AssignUserToTrivia(ctx context.Context, TriviaID, UserID
 	trivia, err := u.triviaRepository.FindByID(ctx, TriviaID)
 	if err != nil {
 		log.WithError(err).Error("Error finding trivia for assigning user in repository")
-		return err
+		return ErrTriviaNotFound
 	}
 
 	user, err := u.userRepository.FindByID(ctx, UserID)
 	if err != nil {
 		log.WithError(err).Error("Error finding user for assigning to trivia in repository")
-		return err
+		return ErrUserNotFound
 	}
 
 	err = u.triviaRepository.AssignUserToTrivia(ctx, trivia.ID, user.ID)
@@ -247,3 +523,408 @@ func (u *TriviaUseCase) AssignUserToTrivia(ctx context.Context, TriviaID, UserID
 	log.Info("User assigned to trivia successfully")
 	return nil
 }
+
+func (u *TriviaUseCase) UnassignUserFromTrivia(ctx context.Context, triviaID, userID uint) error {
+	log := logrus.WithContext(ctx)
+	log.Infof("Unassigning user ID: %d from trivia ID: %d usecase", userID, triviaID)
+
+	if _, err := u.triviaRepository.FindByID(ctx, triviaID); err != nil {
+		log.WithError(err).Error("Error finding trivia for unassigning user in repository")
+		return ErrTriviaNotFound
+	}
+
+	if _, err := u.userRepository.FindByID(ctx, userID); err != nil {
+		log.WithError(err).Error("Error finding user for unassigning from trivia in repository")
+		return ErrUserNotFound
+	}
+
+	if err := u.triviaRepository.UnassignUserFromTrivia(ctx, triviaID, userID); err != nil {
+		log.WithError(err).Error("Error unassigning user from trivia in repository")
+		return err
+	}
+
+	log.Info("User unassigned from trivia successfully")
+	return nil
+}
+
+func (u *TriviaUseCase) GetRanking(ctx context.Context, triviaID uint, page, pageSize int) (responses.RankingResponse, error) {
+	log := logrus.WithContext(ctx)
+	log.Infof("Getting ranking for trivia ID: %d usecase", triviaID)
+
+	if _, err := u.triviaRepository.FindByID(ctx, triviaID); err != nil {
+		log.WithError(err).Error("Error finding trivia for ranking in repository")
+		return responses.RankingResponse{}, ErrTriviaNotFound
+	}
+
+	page, pageSize, offset := shared.NormalizePage(page, pageSize)
+	rankings, total, err := u.triviaRepository.GetTriviaRanking(ctx, triviaID, pageSize, offset)
+	if err != nil {
+		log.WithError(err).Error("Error getting trivia ranking in repository")
+		return responses.RankingResponse{}, err
+	}
+
+	response := responses.RankingResponse{
+		TriviaID: triviaID,
+		Page:     page,
+		PageSize: pageSize,
+		Total:    total,
+		Entries:  []responses.LeaderboardEntryResponse{},
+	}
+	for _, ranking := range rankings {
+		response.Entries = append(response.Entries, responses.LeaderboardEntryResponse{
+			Rank:           ranking.Rank,
+			UserID:         ranking.UserID,
+			Name:           ranking.Name,
+			Score:          ranking.TotalScore,
+			CorrectAnswers: ranking.CorrectAnswers,
+		})
+	}
+
+	log.Info("Trivia ranking retrieved successfully")
+	return response, nil
+}
+
+// GetUserScore returns the breakdown of the counted participation of userID.
+// Unless revealAnswers is set, the correct answers are only included once
+// the user has no attempts left.
+func (u *TriviaUseCase) GetUserScore(ctx context.Context, triviaID, userID uint, revealAnswers bool) (responses.UserScoreResponse, error) {
+	log := logrus.WithContext(ctx)
+	log.Infof("Getting score of user ID: %d for trivia ID: %d usecase", userID, triviaID)
+
+	trivia, err := u.triviaRepository.FindByIDUnscoped(ctx, triviaID)
+	if err != nil {
+		log.WithError(err).Error("Error finding trivia for user score in repository")
+		return responses.UserScoreResponse{}, ErrTriviaNotFound
+	}
+
+	participation, err := u.triviaRepository.GetUserScore(ctx, triviaID, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		log.WithError(err).Error("User has not finished the trivia")
+		return responses.UserScoreResponse{}, ErrParticipationNotFound
+	}
+	if err != nil {
+		log.WithError(err).Error("Error getting user score in repository")
+		return responses.UserScoreResponse{}, err
+	}
+
+	response, err := u.toUserScoreResponse(ctx, participation, trivia, map[uint]models.Question{})
+	if err != nil {
+		log.WithError(err).Error("Error building user score")
+		return responses.UserScoreResponse{}, err
+	}
+	if !revealAnswers {
+		revealAnswers, err = u.answersRevealed(ctx, &trivia, userID)
+		if err != nil {
+			log.WithError(err).Error("Error counting attempts in repository")
+			return responses.UserScoreResponse{}, err
+		}
+	}
+	if !revealAnswers {
+		hideCorrectAnswers(&response)
+	}
+
+	log.Info("User score retrieved successfully")
+	return response, nil
+}
+
+// GetUserParticipations returns the finished participations of userID, with
+// the correct answers hidden as in GetUserScore.
+func (u *TriviaUseCase) GetUserParticipations(ctx context.Context, userID uint, revealAnswers bool) ([]responses.UserScoreResponse, error) {
+	log := logrus.WithContext(ctx)
+	log.Infof("Getting participations of user ID: %d usecase", userID)
+
+	if _, err := u.userRepository.FindByID(ctx, userID); err != nil {
+		log.WithError(err).Error("Error finding user for participations in repository")
+		return nil, ErrUserNotFound
+	}
+
+	participations, err := u.triviaRepository.FindParticipationsByUser(ctx, userID)
+	if err != nil {
+		log.WithError(err).Error("Error finding user participations in repository")
+		return nil, err
+	}
+
+	trivias := map[uint]models.Trivia{}
+	revealed := map[uint]bool{}
+	questions := map[uint]models.Question{}
+	result := []responses.UserScoreResponse{}
+	for _, participation := range participations {
+		trivia, ok := trivias[participation.TriviaID]
+		if !ok {
+			trivia, err = u.triviaRepository.FindByIDUnscoped(ctx, participation.TriviaID)
+			if err != nil {
+				log.WithError(err).Errorf("Error finding trivia ID %d", participation.TriviaID)
+				return nil, err
+			}
+			trivias[trivia.ID] = trivia
+			revealed[trivia.ID] = revealAnswers
+			if !revealAnswers {
+				revealed[trivia.ID], err = u.answersRevealed(ctx, &trivia, userID)
+				if err != nil {
+					log.WithError(err).Error("Error counting attempts in repository")
+					return nil, err
+				}
+			}
+		}
+
+		response, err := u.toUserScoreResponse(ctx, participation, trivia, questions)
+		if err != nil {
+			log.WithError(err).Error("Error building participation")
+			return nil, err
+		}
+		if !revealed[trivia.ID] {
+			hideCorrectAnswers(&response)
+		}
+		result = append(result, response)
+	}
+
+	log.Info("User participations retrieved successfully")
+	return result, nil
+}
+
+// toUserScoreResponse builds the per-answer breakdown of a participation.
+// questions caches the questions already loaded across participations.
+func (u *TriviaUseCase) toUserScoreResponse(ctx context.Context, participation models.Participation, trivia models.Trivia, questions map[uint]models.Question) (responses.UserScoreResponse, error) {
+	response := responses.UserScoreResponse{
+		ParticipationID: participation.ID,
+		TriviaID:        trivia.ID,
+		TriviaName:      trivia.Name,
+		UserID:          participation.UserID,
+		Score:           participation.Score,
+		TotalQuestions:  len(trivia.Questions),
+		StartedAt:       participation.StartedAt,
+		FinishedAt:      participation.FinishedAt,
+		Answers:         []responses.AnswerBreakdownResponse{},
+	}
+
+	for _, answer := range participation.Answers {
+		question, ok := questions[answer.QuestionID]
+		if !ok {
+			var err error
+			question, err = u.triviaRepository.FindQuestionByID(ctx, answer.QuestionID)
+			if err != nil {
+				return responses.UserScoreResponse{}, fmt.Errorf("question ID %d not found: %w", answer.QuestionID, err)
+			}
+			questions[question.ID] = question
+		}
+		question, err := u.answeredRevision(ctx, question, answer)
+		if err != nil {
+			return responses.UserScoreResponse{}, err
+		}
+
+		if answer.IsCorrect {
+			response.CorrectAnswers++
+		}
+		response.Answers = append(response.Answers, responses.AnswerBreakdownResponse{
+			QuestionID:      question.ID,
+			Question:        question.Question,
+			Type:            question.Type,
+			SelectedOption:  answer.SelectedOption,
+			SelectedOptions: answer.SelectedOptions,
+			NumericAnswer:   answer.NumericAnswer,
+			TextAnswer:      answer.TextAnswer,
+			SelectedText:    answerText(question, answer),
+			CorrectOption:   &question.CorrectOption,
+			CorrectText:     correctText(question),
+			IsCorrect:       answer.IsCorrect,
+			TimedOut:        answer.TimedOut,
+			Points:          answer.Points,
+			Explanation:     question.Explanation,
+			ReferenceURL:    question.ReferenceURL,
+		})
+	}
+	return response, nil
+}
+
+// hideCorrectAnswers removes from response what would let the user retake
+// the trivia knowing the answers.
+func hideCorrectAnswers(response *responses.UserScoreResponse) {
+	for i := range response.Answers {
+		response.Answers[i].CorrectOption = nil
+		response.Answers[i].CorrectText = ""
+		response.Answers[i].Explanation = ""
+		response.Answers[i].ReferenceURL = ""
+	}
+}
+
+// answeredRevision returns question as it was when answer was graded. The
+// current question is used for answers older than the recorded revisions.
+func (u *TriviaUseCase) answeredRevision(ctx context.Context, question models.Question, answer models.Answer) (models.Question, error) {
+	if answer.QuestionRevision == question.Revision {
+		return question, nil
+	}
+
+	revision, err := u.triviaRepository.FindQuestionRevision(ctx, question.ID, answer.QuestionRevision)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return question, nil
+	}
+	if err != nil {
+		return models.Question{}, err
+	}
+	return revision.Question(), nil
+}
+
+// optionText returns the text of the option at index, the way options are
+// referenced by Question.CorrectOption and Answer.SelectedOption.
+func optionText(question models.Question, index uint) string {
+	if int(index) >= len(question.Options) {
+		return ""
+	}
+	return question.Options[index].Text
+}
+
+// answerText returns a readable version of answer for the question type.
+func answerText(question models.Question, answer models.Answer) string {
+	if answer.TimedOut {
+		return ""
+	}
+
+	switch question.Type {
+	case models.QuestionMultiSelect:
+		var texts []string
+		for _, index := range answer.SelectedOptions {
+			texts = append(texts, optionText(question, index))
+		}
+		return strings.Join(texts, ", ")
+	case models.QuestionNumeric:
+		if answer.NumericAnswer == nil {
+			return ""
+		}
+		return strconv.FormatFloat(*answer.NumericAnswer, 'f', -1, 64)
+	case models.QuestionFreeText:
+		return answer.TextAnswer
+	}
+	return optionText(question, answer.SelectedOption)
+}
+
+// correctText returns a readable version of the right answer of question.
+func correctText(question models.Question) string {
+	switch question.Type {
+	case models.QuestionMultiSelect:
+		var texts []string
+		for _, option := range question.Options {
+			if option.IsCorrect {
+				texts = append(texts, option.Text)
+			}
+		}
+		return strings.Join(texts, ", ")
+	case models.QuestionNumeric:
+		if question.NumericAnswer == nil {
+			return ""
+		}
+		text := strconv.FormatFloat(*question.NumericAnswer, 'f', -1, 64)
+		if question.Tolerance > 0 {
+			text += " ± " + strconv.FormatFloat(question.Tolerance, 'f', -1, 64)
+		}
+		return text
+	case models.QuestionFreeText:
+		if len(question.Options) == 0 {
+			return ""
+		}
+		return question.Options[0].Text
+	}
+	return optionText(question, question.CorrectOption)
+}
+
+func toAuthorTriviaResponse(trivia models.Trivia) responses.AuthorTriviaResponse {
+	var questionResponses []responses.AuthorQuestionResponse
+	for _, question := range trivia.Questions {
+		var optionResponses []responses.OptionResponse
+		var correctOptions []uint
+		var acceptedAnswers []string
+		for i, option := range question.Options {
+			optionResponses = append(optionResponses, responses.OptionResponse{
+				Index:  i,
+				Option: option.Text,
+			})
+			if option.IsCorrect {
+				correctOptions = append(correctOptions, uint(i))
+			}
+			if question.Type == models.QuestionFreeText {
+				acceptedAnswers = append(acceptedAnswers, option.Text)
+			}
+		}
+
+		questionResponses = append(questionResponses, responses.AuthorQuestionResponse{
+			ID:               question.ID,
+			Question:         question.Question,
+			Type:             question.Type,
+			CorrectOption:    question.CorrectOption,
+			CorrectOptions:   correctOptions,
+			PartialCredit:    question.PartialCredit,
+			NumericAnswer:    question.NumericAnswer,
+			Tolerance:        question.Tolerance,
+			AcceptedAnswers:  acceptedAnswers,
+			Options:          optionResponses,
+			Difficulty:       question.Difficulty,
+			Points:           question.Points,
+			TimeLimitSeconds: question.TimeLimitSeconds,
+			Explanation:      question.Explanation,
+			ReferenceURL:     question.ReferenceURL,
+			Revision:         question.Revision,
+			Status:           question.Status,
+		})
+	}
+
+	var userResponses []responses.UserResponse
+	for _, user := range trivia.Users {
+		userResponses = append(userResponses, responses.UserResponse{
+			ID:         user.ID,
+			Name:       user.Name,
+			Email:      user.Email,
+			Department: user.Department,
+		})
+	}
+
+	return responses.AuthorTriviaResponse{
+		ID:                trivia.ID,
+		Name:              trivia.Name,
+		Description:       trivia.Description,
+		ScoringStrategy:   trivia.ScoringStrategy,
+		TimeLimitSeconds:  trivia.TimeLimitSeconds,
+		MaxAttempts:       trivia.MaxAttempts,
+		CooldownSeconds:   trivia.CooldownSeconds,
+		RankingPolicy:     trivia.RankingPolicy,
+		RequireAllAnswers: trivia.RequireAllAnswers,
+		ShuffleQuestions:  trivia.ShuffleQuestions,
+		ShuffleOptions:    trivia.ShuffleOptions,
+		QuestionPoolSize:  trivia.QuestionPoolSize,
+		Status:            trivia.Status,
+		Questions:         questionResponses,
+		Users:             userResponses,
+	}
+}
+
+func validateScoringStrategy(strategy string) error {
+	switch strategy {
+	case "", models.ScoringQuestionPoints, models.ScoringDifficulty, models.ScoringNegativeMarking, models.ScoringTimeBonus:
+		return nil
+	}
+	return fmt.Errorf("invalid scoring strategy %q", strategy)
+}
+
+func validateAttemptPolicy(req *requests.CreateTriviaRequest) error {
+	if req.MaxAttempts < 0 {
+		return errors.New("max attempts cannot be negative")
+	}
+	if req.CooldownSeconds < 0 {
+		return errors.New("cooldown cannot be negative")
+	}
+	switch req.RankingPolicy {
+	case "", models.RankingBest, models.RankingLatest, models.RankingFirst:
+		return nil
+	}
+	return fmt.Errorf("invalid ranking policy %q", req.RankingPolicy)
+}
+
+// validateQuestionPool checks that the pool size fits the questions of the
+// trivia, when they are part of the request.
+func validateQuestionPool(req *requests.CreateTriviaRequest) error {
+	if req.QuestionPoolSize < 0 {
+		return errors.New("question pool size cannot be negative")
+	}
+	if len(req.QuestionIDs) > 0 && req.QuestionPoolSize > len(req.QuestionIDs) {
+		return fmt.Errorf("question pool size %d exceeds the %d questions of the trivia", req.QuestionPoolSize, len(req.QuestionIDs))
+	}
+	return nil
+}
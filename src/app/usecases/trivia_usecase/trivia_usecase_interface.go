@@ -7,9 +7,23 @@ import (
 )
 
 type TriviaUseCaseInterface interface {
-	FindAll(ctx context.Context) ([]responses.TriviaResponse, error)
-	FindByID(ctx context.Context, id uint) (responses.TriviaResponse, error)
+	FindAll(ctx context.Context, publishedOnly bool) ([]responses.TriviaResponse, error)
+	FindByID(ctx context.Context, id uint, publishedOnly bool) (responses.TriviaResponse, error)
+	FindByIDForAuthor(ctx context.Context, id uint) (responses.AuthorTriviaResponse, error)
+	GetReview(ctx context.Context, triviaID, userID uint) (responses.AuthorTriviaResponse, error)
 	CreateTrivia(ctx context.Context, req *requests.CreateTriviaRequest) error
+	GenerateTrivia(ctx context.Context, req *requests.GenerateTriviaRequest) (responses.AuthorTriviaResponse, error)
+	ExportTrivia(ctx context.Context, id uint) (responses.ContentBundle, error)
+	ImportTrivia(ctx context.Context, bundle *responses.ContentBundle) (responses.AuthorTriviaResponse, error)
 	UpdateTrivia(ctx context.Context, req *requests.CreateTriviaRequest, id uint) error
+	ChangeTriviaStatus(ctx context.Context, id uint, req *requests.StatusChangeRequest) (responses.AuthorTriviaResponse, error)
+	TriviaReviews(ctx context.Context, id uint) ([]responses.ReviewEventResponse, error)
 	DeleteTrivia(ctx context.Context, id uint) error
+	RestoreTrivia(ctx context.Context, id uint) error
+	PurgeTrivia(ctx context.Context, id uint) error
+	AssignUserToTrivia(ctx context.Context, triviaID, userID uint) error
+	UnassignUserFromTrivia(ctx context.Context, triviaID, userID uint) error
+	GetRanking(ctx context.Context, triviaID uint, page, pageSize int) (responses.RankingResponse, error)
+	GetUserScore(ctx context.Context, triviaID, userID uint, revealAnswers bool) (responses.UserScoreResponse, error)
+	GetUserParticipations(ctx context.Context, userID uint, revealAnswers bool) ([]responses.UserScoreResponse, error)
 }
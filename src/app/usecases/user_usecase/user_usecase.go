@@ -2,13 +2,21 @@ package usecases
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"talana_prueba_tecnica/src/entity/models"
 	"talana_prueba_tecnica/src/entity/requests"
 	"talana_prueba_tecnica/src/entity/responses"
 	repository "talana_prueba_tecnica/src/infraestructure/repository/user_repository"
+	"talana_prueba_tecnica/src/shared"
 
 	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrUserNotFound = errors.New("user not found")
+	ErrInvalidRole  = errors.New("invalid role, must be one of admin, author or player")
 )
 
 type UserUseCase struct {
@@ -36,9 +44,11 @@ func (u *UserUseCase) FindAll(ctx context.Context) ([]responses.UserResponse, er
 
 	for _, users := range result {
 		responseUsers := responses.UserResponse{
-			ID:    users.ID,
-			Name:  users.Name,
-			Email: users.Email,
+			ID:         users.ID,
+			Name:       users.Name,
+			Email:      users.Email,
+			Department: users.Department,
+			Roles:      users.RoleNames(),
 		}
 		usersList = append(usersList, responseUsers)
 	}
@@ -58,9 +68,11 @@ func (u *UserUseCase) GetUserByID(ctx context.Context, id uint) (responses.UserR
 
 	log.Info("User found")
 	responseUsers := responses.UserResponse{
-		ID:    result.ID,
-		Name:  result.Name,
-		Email: result.Email,
+		ID:         result.ID,
+		Name:       result.Name,
+		Email:      result.Email,
+		Department: result.Department,
+		Roles:      result.RoleNames(),
 	}
 
 	return responseUsers, nil
@@ -70,12 +82,21 @@ func (u *UserUseCase) CreateUser(ctx context.Context, user requests.RegisterUser
 	log := logrus.WithContext(ctx)
 	log.Info("Create user usecase")
 
+	passwordHash, err := shared.HashPassword(user.Password)
+	if err != nil {
+		log.WithError(err).Error("Invalid password")
+		return err
+	}
+
 	userModel := models.UserModel{
-		Name:  user.Name,
-		Email: user.Email,
+		Name:         user.Name,
+		Email:        user.Email,
+		Department:   user.Department,
+		PasswordHash: passwordHash,
+		Roles:        shared.InitialRoles(),
 	}
 
-	err := u.repository.Create(ctx, &userModel)
+	err = u.repository.Create(ctx, &userModel)
 
 	if err != nil {
 		log.WithError(err).Error("Error creating user")
@@ -99,6 +120,7 @@ func (u *UserUseCase) UpdateUser(ctx context.Context, id uint, user requests.Upd
 	fmt.Print(existingUser)
 	existingUser.Name = user.Name
 	existingUser.Email = user.Email
+	existingUser.Department = user.Department
 
 	err = u.repository.Update(ctx, existingUser, id)
 	if err != nil {
@@ -130,3 +152,87 @@ func (u *UserUseCase) DeleteUser(ctx context.Context, id uint) error {
 	log.Info("User deleted")
 	return nil
 }
+
+// RestoreUser brings back a deleted user, who can sign in again.
+func (u *UserUseCase) RestoreUser(ctx context.Context, id uint) error {
+	log := logrus.WithContext(ctx)
+	log.Infof("Restore user %d usecase", id)
+
+	err := u.repository.Restore(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Error("Deleted user not found")
+		return ErrUserNotFound
+	}
+	if err != nil {
+		log.WithError(err).Error("Error restoring user")
+		return err
+	}
+
+	log.Info("User restored")
+	return nil
+}
+
+// PurgeUser permanently removes a deleted user and their participations.
+func (u *UserUseCase) PurgeUser(ctx context.Context, id uint) error {
+	log := logrus.WithContext(ctx)
+	log.Infof("Purge user %d usecase", id)
+
+	err := u.repository.Purge(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Error("Deleted user not found")
+		return ErrUserNotFound
+	}
+	if err != nil {
+		log.WithError(err).Error("Error purging user")
+		return err
+	}
+
+	log.Info("User purged")
+	return nil
+}
+
+func (u *UserUseCase) GrantRole(ctx context.Context, id uint, role string) error {
+	log := logrus.WithContext(ctx)
+	log.Infof("Grant role %s to user %d usecase", role, id)
+
+	if !models.IsValidRole(role) {
+		log.Errorf("Invalid role %s", role)
+		return ErrInvalidRole
+	}
+
+	if _, err := u.repository.FindByID(ctx, id); err != nil {
+		log.WithError(err).Error("Error finding user")
+		return ErrUserNotFound
+	}
+
+	if err := u.repository.AddRole(ctx, id, role); err != nil {
+		log.WithError(err).Error("Error granting role")
+		return err
+	}
+
+	log.Info("Role granted")
+	return nil
+}
+
+func (u *UserUseCase) RevokeRole(ctx context.Context, id uint, role string) error {
+	log := logrus.WithContext(ctx)
+	log.Infof("Revoke role %s from user %d usecase", role, id)
+
+	if !models.IsValidRole(role) {
+		log.Errorf("Invalid role %s", role)
+		return ErrInvalidRole
+	}
+
+	if _, err := u.repository.FindByID(ctx, id); err != nil {
+		log.WithError(err).Error("Error finding user")
+		return ErrUserNotFound
+	}
+
+	if err := u.repository.RemoveRole(ctx, id, role); err != nil {
+		log.WithError(err).Error("Error revoking role")
+		return err
+	}
+
+	log.Info("Role revoked")
+	return nil
+}
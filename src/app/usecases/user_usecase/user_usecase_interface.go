@@ -12,4 +12,8 @@ type UserUseCaseInterface interface {
 	CreateUser(ctx context.Context, user requests.RegisterUserRequest) error
 	UpdateUser(ctx context.Context, id uint, user requests.UpdateUserRequest) error
 	DeleteUser(ctx context.Context, id uint) error
+	RestoreUser(ctx context.Context, id uint) error
+	PurgeUser(ctx context.Context, id uint) error
+	GrantRole(ctx context.Context, id uint, role string) error
+	RevokeRole(ctx context.Context, id uint, role string) error
 }
@@ -1,9 +1,20 @@
 package models
 
+import "time"
+
+// Answer stores the answer in the field matching the question type.
 type Answer struct {
-	ID              uint `gorm:"primaryKey"`
-	ParticipationID uint `gorm:"not null"`
-	QuestionID      uint `gorm:"not null"`
-	SelectedOption  uint `gorm:"not null"`
-	IsCorrect       bool `gorm:"not null"`
+	ID               uint   `gorm:"primaryKey"`
+	ParticipationID  uint   `gorm:"not null;uniqueIndex:idx_answer_question"`
+	QuestionID       uint   `gorm:"not null;uniqueIndex:idx_answer_question"`
+	QuestionRevision int    `gorm:"not null;default:1"`
+	SelectedOption   uint   `gorm:"not null"`
+	SelectedOptions  []uint `gorm:"type:jsonb;serializer:json"`
+	NumericAnswer    *float64
+	TextAnswer       string `gorm:"size:255"`
+	IsCorrect        bool   `gorm:"not null"`
+	Points           int    `gorm:"not null;default:0"`
+	TimedOut         bool   `gorm:"not null;default:false"`
+	ServedAt         *time.Time
+	AnsweredAt       *time.Time
 }
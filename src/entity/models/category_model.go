@@ -0,0 +1,11 @@
+package models
+
+// Category groups questions by topic. Categories nest through ParentID and
+// filtering questions by a category includes those of its subcategories.
+type Category struct {
+	ID       uint   `gorm:"primaryKey"`
+	Name     string `gorm:"size:100;not null;uniqueIndex:idx_category_name"`
+	ParentID *uint  `gorm:"uniqueIndex:idx_category_name"`
+	Parent   *Category
+	Children []Category `gorm:"foreignKey:ParentID"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+const (
+	SessionStatusActive    = "active"
+	SessionStatusCompleted = "completed"
+	SessionStatusExpired   = "expired"
+)
+
+type GameSession struct {
+	ID              uint          `gorm:"primaryKey"`
+	UserID          uint          `gorm:"not null;index"`
+	TriviaID        uint          `gorm:"not null;index"`
+	ParticipationID uint          `gorm:"not null"`
+	Participation   Participation `gorm:"foreignKey:ParticipationID;constraint:OnDelete:CASCADE;"`
+	Status          string        `gorm:"type:VARCHAR(15);not null;default:'active'"`
+	StartedAt       time.Time     `gorm:"not null"`
+	FinishedAt      *time.Time
+	Questions       []SessionQuestion `gorm:"foreignKey:SessionID;constraint:OnDelete:CASCADE;"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// IdempotencyRecord stores the response replayed to retries of a request
+// sent with an Idempotency-Key. StatusCode is 0 while the request runs.
+type IdempotencyRecord struct {
+	ID          uint   `gorm:"primaryKey"`
+	UserID      uint   `gorm:"not null;uniqueIndex:idx_idempotency_key"`
+	Key         string `gorm:"size:255;not null;uniqueIndex:idx_idempotency_key"`
+	RequestHash string `gorm:"size:64;not null"`
+	StatusCode  int
+	ContentType string `gorm:"size:100"`
+	Body        []byte
+	CreatedAt   time.Time
+}
@@ -3,5 +3,6 @@ package models
 type Option struct {
 	ID         uint   `gorm:"primaryKey"`
 	Text       string `gorm:"not null"`
+	IsCorrect  bool   `gorm:"not null;default:false"`
 	QuestionID uint   `gorm:"not null"`
 }
@@ -1,9 +1,16 @@
 package models
 
+import "time"
+
+// Participation is an attempt of a user at a trivia.
 type Participation struct {
-	ID       uint     `gorm:"primaryKey"`
-	UserID   uint     `gorm:"not null"`
-	TriviaID uint     `gorm:"not null"`
-	Score    int      `gorm:"not null"`
-	Answers  []Answer `gorm:"foreignKey:ParticipationID;constraint:OnDelete:CASCADE;"`
+	ID             uint    `gorm:"primaryKey"`
+	UserID         uint    `gorm:"not null;uniqueIndex:idx_participation_idempotency_key"`
+	TriviaID       uint    `gorm:"not null"`
+	Score          int     `gorm:"not null"`
+	IdempotencyKey *string `gorm:"size:255;uniqueIndex:idx_participation_idempotency_key"`
+	ShuffleSeed    int64   `gorm:"not null;default:0"` // fixes the order of the questions and options served
+	StartedAt      *time.Time
+	FinishedAt     *time.Time
+	Answers        []Answer `gorm:"foreignKey:ParticipationID;constraint:OnDelete:CASCADE;"`
 }
@@ -0,0 +1,85 @@
+package models
+
+import "time"
+
+// QuestionRevision is an immutable snapshot of a question, recorded when it
+// is created and on every update.
+type QuestionRevision struct {
+	ID         uint             `gorm:"primaryKey"`
+	QuestionID uint             `gorm:"not null;uniqueIndex:idx_question_revision"`
+	Revision   int              `gorm:"not null;uniqueIndex:idx_question_revision"`
+	Snapshot   QuestionSnapshot `gorm:"type:jsonb;serializer:json;not null"`
+	CreatedAt  time.Time
+}
+
+// QuestionSnapshot holds the fields of a question that players see or that
+// grading depends on, with its options in order.
+type QuestionSnapshot struct {
+	Question         string           `json:"question"`
+	Type             string           `json:"type"`
+	Options          []SnapshotOption `json:"options"`
+	CorrectOption    uint             `json:"correct_option"`
+	PartialCredit    bool             `json:"partial_credit"`
+	NumericAnswer    *float64         `json:"numeric_answer,omitempty"`
+	Tolerance        float64          `json:"tolerance"`
+	Difficulty       string           `json:"difficulty"`
+	Points           int              `json:"points"`
+	TimeLimitSeconds int              `json:"time_limit_seconds"`
+	Explanation      string           `json:"explanation,omitempty"`
+	ReferenceURL     string           `json:"reference_url,omitempty"`
+}
+
+type SnapshotOption struct {
+	Text      string `json:"text"`
+	IsCorrect bool   `json:"is_correct"`
+}
+
+// NewQuestionRevision snapshots question as its current Revision.
+func NewQuestionRevision(question *Question) *QuestionRevision {
+	snapshot := QuestionSnapshot{
+		Question:         question.Question,
+		Type:             question.Type,
+		Options:          []SnapshotOption{},
+		CorrectOption:    question.CorrectOption,
+		PartialCredit:    question.PartialCredit,
+		NumericAnswer:    question.NumericAnswer,
+		Tolerance:        question.Tolerance,
+		Difficulty:       question.Difficulty,
+		Points:           question.Points,
+		TimeLimitSeconds: question.TimeLimitSeconds,
+		Explanation:      question.Explanation,
+		ReferenceURL:     question.ReferenceURL,
+	}
+	for _, option := range question.Options {
+		snapshot.Options = append(snapshot.Options, SnapshotOption{Text: option.Text, IsCorrect: option.IsCorrect})
+	}
+	return &QuestionRevision{
+		QuestionID: question.ID,
+		Revision:   question.Revision,
+		Snapshot:   snapshot,
+	}
+}
+
+// Question rebuilds the question as it was in the revision. Options have no
+// ID and categories and tags are not included.
+func (r *QuestionRevision) Question() Question {
+	question := Question{
+		ID:               r.QuestionID,
+		Question:         r.Snapshot.Question,
+		Type:             r.Snapshot.Type,
+		CorrectOption:    r.Snapshot.CorrectOption,
+		PartialCredit:    r.Snapshot.PartialCredit,
+		NumericAnswer:    r.Snapshot.NumericAnswer,
+		Tolerance:        r.Snapshot.Tolerance,
+		Difficulty:       r.Snapshot.Difficulty,
+		Points:           r.Snapshot.Points,
+		TimeLimitSeconds: r.Snapshot.TimeLimitSeconds,
+		Explanation:      r.Snapshot.Explanation,
+		ReferenceURL:     r.Snapshot.ReferenceURL,
+		Revision:         r.Revision,
+	}
+	for _, option := range r.Snapshot.Options {
+		question.Options = append(question.Options, Option{Text: option.Text, IsCorrect: option.IsCorrect, QuestionID: r.QuestionID})
+	}
+	return question
+}
@@ -1,10 +1,60 @@
 package models
 
+import "gorm.io/gorm"
+
+const (
+	QuestionSingleChoice = "single_choice"
+	QuestionTrueFalse    = "true_false"
+	QuestionMultiSelect  = "multi_select"
+	QuestionNumeric      = "numeric"
+	QuestionFreeText     = "free_text"
+
+	DefaultQuestionType = QuestionSingleChoice
+)
+
+// Question is a question of the trivias, answered according to its Type.
 type Question struct {
-	ID            uint     `gorm:"primaryKey,autoIncrement,not null"`
-	Question      string   `gorm:"size:255;not null"`
-	Options       []Option `gorm:"foreignKey:QuestionID"`
-	CorrectOption uint     `gorm:"not null"`
-	Difficulty    string   `gorm:"type:VARCHAR(10);not null;check:difficulty IN ('facil', 'medio', 'dificil')"`
-	Points        int      `gorm:"not null"`
+	ID               uint     `gorm:"primaryKey,autoIncrement,not null"`
+	Question         string   `gorm:"size:255;not null"`
+	Type             string   `gorm:"type:VARCHAR(20);not null;default:'single_choice';check:type IN ('single_choice', 'true_false', 'multi_select', 'numeric', 'free_text')"`
+	Options          []Option `gorm:"foreignKey:QuestionID"` // flagged IsCorrect for multi_select, the accepted answers for free_text
+	CorrectOption    uint     `gorm:"not null"`              // index of the right option for single_choice and true_false
+	PartialCredit    bool     `gorm:"not null;default:false"`
+	NumericAnswer    *float64
+	Tolerance        float64        `gorm:"not null;default:0"` // accepted distance from NumericAnswer for numeric
+	Difficulty       string         `gorm:"type:VARCHAR(10);not null;check:difficulty IN ('facil', 'medio', 'dificil')"`
+	Points           int            `gorm:"not null"`
+	TimeLimitSeconds int            `gorm:"not null;default:0"`
+	Explanation      string         `gorm:"type:text"`
+	ReferenceURL     string         `gorm:"size:2048"`
+	Categories       []Category     `gorm:"many2many:question_categories;constraint:OnDelete:CASCADE;"`
+	Tags             []Tag          `gorm:"many2many:question_tags;constraint:OnDelete:CASCADE;"`
+	Revision         int            `gorm:"not null;default:1"`
+	Status           string         `gorm:"type:VARCHAR(10);not null;default:'published';check:status IN ('draft', 'in_review', 'published', 'archived')"`
+	DeletedAt        gorm.DeletedAt `gorm:"index"`
+}
+
+func IsValidQuestionType(questionType string) bool {
+	switch questionType {
+	case QuestionSingleChoice, QuestionTrueFalse, QuestionMultiSelect, QuestionNumeric, QuestionFreeText:
+		return true
+	}
+	return false
+}
+
+// HasChoices reports whether players answer the question by picking options.
+func (q *Question) HasChoices() bool {
+	switch q.Type {
+	case QuestionNumeric, QuestionFreeText:
+		return false
+	}
+	return true
+}
+
+// PlayerOptions returns the options players may see.
+func (q *Question) PlayerOptions() []Option {
+	if !q.HasChoices() {
+		return nil
+	}
+	return q.Options
 }
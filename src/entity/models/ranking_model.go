@@ -1,7 +1,9 @@
 package models
 
 type Ranking struct {
-	ID     uint `gorm:"primaryKey"`
-	UserID uint `gorm:"not null"`
-	Score  uint `gorm:"not null"`
+	Rank           int
+	UserID         uint
+	Name           string
+	TotalScore     int
+	CorrectAnswers int
 }
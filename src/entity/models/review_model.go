@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+// Editorial statuses of questions and trivias. Only published content is
+// served to players.
+const (
+	StatusDraft     = "draft"
+	StatusInReview  = "in_review"
+	StatusPublished = "published"
+	StatusArchived  = "archived"
+)
+
+const (
+	ContentQuestion = "question"
+	ContentTrivia   = "trivia"
+)
+
+// statusTransitions lists the statuses each status can move to.
+var statusTransitions = map[string][]string{
+	StatusDraft:     {StatusInReview},
+	StatusInReview:  {StatusPublished, StatusDraft},
+	StatusPublished: {StatusArchived},
+	StatusArchived:  {StatusDraft},
+}
+
+func IsValidStatus(status string) bool {
+	_, ok := statusTransitions[status]
+	return ok
+}
+
+// CanTransition reports whether content in status from can move to to.
+func CanTransition(from, to string) bool {
+	for _, allowed := range statusTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// ReviewEvent records a status change of a question or trivia, with the
+// comment of the user who made it.
+type ReviewEvent struct {
+	ID          uint   `gorm:"primaryKey"`
+	ContentType string `gorm:"type:VARCHAR(10);not null;index:idx_review_content;check:content_type IN ('question', 'trivia')"`
+	ContentID   uint   `gorm:"not null;index:idx_review_content"`
+	FromStatus  string `gorm:"type:VARCHAR(10);not null"`
+	ToStatus    string `gorm:"type:VARCHAR(10);not null"`
+	Comment     string `gorm:"type:text"`
+	UserID      uint   `gorm:"not null"`
+	CreatedAt   time.Time
+}
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+type Season struct {
+	ID       uint      `gorm:"primaryKey"`
+	Name     string    `gorm:"size:100;not null"`
+	Slug     string    `gorm:"size:50;not null;unique"`
+	StartsAt time.Time `gorm:"not null"`
+	EndsAt   time.Time `gorm:"not null"`
+}
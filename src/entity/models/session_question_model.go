@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// SessionQuestion is a question drawn for a game session, in the Position it
+// is served.
+type SessionQuestion struct {
+	ID               uint `gorm:"primaryKey"`
+	SessionID        uint `gorm:"not null;index"`
+	QuestionID       uint `gorm:"not null"`
+	QuestionRevision int  `gorm:"not null;default:1"` // revision of the question when it was served
+	Position         int  `gorm:"not null"`
+	ServedAt         *time.Time
+	AnsweredAt       *time.Time
+}
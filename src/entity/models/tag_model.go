@@ -0,0 +1,17 @@
+package models
+
+import "strings"
+
+const MaxTagNameLength = 50
+
+// Tag is a free-form label for questions. Names are stored normalized with
+// NormalizeTagName.
+type Tag struct {
+	ID   uint   `gorm:"primaryKey"`
+	Name string `gorm:"size:50;not null;unique"`
+}
+
+// NormalizeTagName lowercases name and collapses its whitespace.
+func NormalizeTagName(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+}
@@ -1,9 +1,67 @@
 package models
 
+import "gorm.io/gorm"
+
+const (
+	ScoringQuestionPoints  = "question_points"
+	ScoringDifficulty      = "difficulty"
+	ScoringNegativeMarking = "negative_marking"
+	ScoringTimeBonus       = "time_bonus"
+	DefaultScoringStrategy = ScoringQuestionPoints
+)
+
+// Ranking policies decide which attempt of a user counts in the rankings.
+const (
+	RankingBest          = "best"
+	RankingLatest        = "latest"
+	RankingFirst         = "first"
+	DefaultRankingPolicy = RankingBest
+)
+
+// Trivia is a set of questions played by the users assigned to it.
 type Trivia struct {
-	ID          uint        `gorm:"primaryKey"`
-	Name        string      `gorm:"not null"`
-	Description string      `gorm:"not null"`
-	Questions   []Question  `gorm:"many2many:trivia_questions;"`
-	Users       []UserModel `gorm:"many2many:trivia_users;"`
+	ID                uint           `gorm:"primaryKey"`
+	Name              string         `gorm:"not null"`
+	Description       string         `gorm:"not null"`
+	ScoringStrategy   string         `gorm:"type:VARCHAR(20);not null;default:'question_points';check:scoring_strategy IN ('question_points', 'difficulty', 'negative_marking', 'time_bonus')"`
+	TimeLimitSeconds  int            `gorm:"not null;default:0"`
+	MaxAttempts       int            `gorm:"not null;default:0"`
+	CooldownSeconds   int            `gorm:"not null;default:0"`
+	RankingPolicy     string         `gorm:"type:VARCHAR(10);not null;default:'best';check:ranking_policy IN ('best', 'latest', 'first')"`
+	RequireAllAnswers bool           `gorm:"not null;default:false"`
+	ShuffleQuestions  bool           `gorm:"not null;default:false"`
+	ShuffleOptions    bool           `gorm:"not null;default:false"`
+	QuestionPoolSize  int            `gorm:"not null;default:0"` // questions served to each participant, all of them when 0
+	Status            string         `gorm:"type:VARCHAR(10);not null;default:'published';check:status IN ('draft', 'in_review', 'published', 'archived')"`
+	DeletedAt         gorm.DeletedAt `gorm:"index"`
+	Questions         []Question     `gorm:"many2many:trivia_questions;"`
+	Users             []UserModel    `gorm:"many2many:trivia_users;"`
+}
+
+// Randomized reports whether participants may see the questions of the
+// trivia in a different order or subset than the trivia itself.
+func (t *Trivia) Randomized() bool {
+	return t.ShuffleQuestions || t.ShuffleOptions || t.QuestionPoolSize > 0
+}
+
+// PublishedQuestions returns the questions of the trivia players may be
+// served.
+func (t *Trivia) PublishedQuestions() []Question {
+	var questions []Question
+	for _, question := range t.Questions {
+		if question.Status == StatusPublished {
+			questions = append(questions, question)
+		}
+	}
+	return questions
+}
+
+// ServedQuestionCount is the number of questions each participant of the
+// trivia is served.
+func (t *Trivia) ServedQuestionCount() int {
+	published := len(t.PublishedQuestions())
+	if t.QuestionPoolSize > 0 && t.QuestionPoolSize < published {
+		return t.QuestionPoolSize
+	}
+	return published
 }
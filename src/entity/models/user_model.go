@@ -1,7 +1,33 @@
 package models
 
+import "gorm.io/gorm"
+
 type UserModel struct {
-	ID    uint   `gorm:"primaryKey;autoIncrement;not null"`
-	Name  string `gorm:"size:50;not null"`
-	Email string `gorm:"size:30;not null;unique"`
+	ID           uint           `gorm:"primaryKey;autoIncrement;not null"`
+	Name         string         `gorm:"size:50;not null"`
+	Email        string         `gorm:"size:30;not null;unique"`
+	Department   string         `gorm:"size:50;index"`
+	PasswordHash string         `gorm:"size:60"`
+	Roles        []UserRole     `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;"`
+	DeletedAt    gorm.DeletedAt `gorm:"index"`
+}
+
+// HasRole reports whether the user holds any of roles.
+func (u *UserModel) HasRole(roles ...string) bool {
+	for _, granted := range u.Roles {
+		for _, role := range roles {
+			if granted.Role == role {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (u *UserModel) RoleNames() []string {
+	names := []string{}
+	for _, role := range u.Roles {
+		names = append(names, role.Role)
+	}
+	return names
 }
@@ -0,0 +1,21 @@
+package models
+
+const (
+	RoleAdmin  = "admin"
+	RoleAuthor = "author"
+	RolePlayer = "player"
+)
+
+type UserRole struct {
+	ID     uint   `gorm:"primaryKey"`
+	UserID uint   `gorm:"not null;uniqueIndex:idx_user_role"`
+	Role   string `gorm:"type:VARCHAR(10);not null;uniqueIndex:idx_user_role;check:role IN ('admin', 'author', 'player')"`
+}
+
+func IsValidRole(role string) bool {
+	switch role {
+	case RoleAdmin, RoleAuthor, RolePlayer:
+		return true
+	}
+	return false
+}
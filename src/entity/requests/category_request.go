@@ -0,0 +1,10 @@
+package requests
+
+type CreateCategoryRequest struct {
+	Name     string `json:"name"`
+	ParentID *uint  `json:"parent_id"`
+}
+
+type CreateTagRequest struct {
+	Name string `json:"name"`
+}
@@ -0,0 +1,15 @@
+package requests
+
+// StartSessionRequest starts a game session of a trivia.
+type StartSessionRequest struct {
+	UserID uint `json:"-"` // filled from the authenticated user
+}
+
+// SessionAnswerRequest has the same answer shapes as AnswerRequest.
+type SessionAnswerRequest struct {
+	QuestionID      uint     `json:"question_id"`
+	SelectedOption  uint     `json:"selected_option"`
+	SelectedOptions []uint   `json:"selected_options,omitempty"`
+	NumericAnswer   *float64 `json:"numeric_answer,omitempty"`
+	TextAnswer      string   `json:"text_answer,omitempty"`
+}
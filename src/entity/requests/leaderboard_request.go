@@ -0,0 +1,19 @@
+package requests
+
+import "time"
+
+type LeaderboardQuery struct {
+	From       *time.Time
+	To         *time.Time
+	Department string
+	Difficulty string
+	Page       int
+	PageSize   int
+}
+
+type CreateSeasonRequest struct {
+	Name     string    `json:"name"`
+	Slug     string    `json:"slug"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+}
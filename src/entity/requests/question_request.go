@@ -1,9 +1,43 @@
 package requests
 
+// CreateQuestionRequest fields used depend on Type (single_choice when
+// empty): CorrectOption for single_choice and true_false, CorrectOptions and
+// PartialCredit for multi_select, NumericAnswer and Tolerance for numeric and
+// AcceptedAnswers for free_text. True/false questions default their options
+// to "True" and "False".
 type CreateQuestionRequest struct {
-	Question      string   `json:"question"`
-	Difficulty    string   `json:"difficulty"`
-	Points        int      `json:"points"`
-	Options       []string `json:"options"`
-	CorrectOption int      `json:"correct_option"`
+	Question         string   `json:"question"`
+	Type             string   `json:"type"`
+	Difficulty       string   `json:"difficulty"`
+	Points           int      `json:"points"`
+	Options          []string `json:"options"`
+	CorrectOption    int      `json:"correct_option"`
+	CorrectOptions   []int    `json:"correct_options"`
+	PartialCredit    bool     `json:"partial_credit"`
+	NumericAnswer    *float64 `json:"numeric_answer"`
+	Tolerance        float64  `json:"tolerance"`
+	AcceptedAnswers  []string `json:"accepted_answers"`
+	TimeLimitSeconds int      `json:"time_limit_seconds"`
+	Explanation      string   `json:"explanation"`
+	ReferenceURL     string   `json:"reference_url"`
+	CategoryIDs      []uint   `json:"category_ids"`
+	Tags             []string `json:"tags"`
+}
+
+// QuestionQuery filters question listings; zero values match every question.
+type QuestionQuery struct {
+	CategoryID uint
+	Tag        string
+	Difficulty string
+	Status     string
+}
+
+// ImportQuestionsRequest is filled by the handler from the uploaded file and
+// the format and dry_run query parameters. An empty Format is detected from
+// ContentType and Data.
+type ImportQuestionsRequest struct {
+	Format      string
+	DryRun      bool
+	ContentType string
+	Data        []byte
 }
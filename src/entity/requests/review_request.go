@@ -0,0 +1,12 @@
+package requests
+
+// StatusChangeRequest moves a question or trivia to Status. Comment is
+// required when a reviewer sends content back to draft. UserID and
+// Reviewer are filled from the authenticated user, not from the request
+// body.
+type StatusChangeRequest struct {
+	Status   string `json:"status"`
+	Comment  string `json:"comment"`
+	UserID   uint   `json:"-"`
+	Reviewer bool   `json:"-"`
+}
@@ -0,0 +1,17 @@
+package requests
+
+// CreateRoomRequest opens a live room for a trivia.
+type CreateRoomRequest struct {
+	TriviaID        uint `json:"trivia_id"`
+	HostID          uint `json:"-"` // filled from the authenticated user
+	QuestionSeconds int  `json:"question_seconds"`
+}
+
+type RoomMessage struct {
+	Type            string   `json:"type"`
+	QuestionID      uint     `json:"question_id"`
+	SelectedOption  uint     `json:"selected_option"`
+	SelectedOptions []uint   `json:"selected_options,omitempty"`
+	NumericAnswer   *float64 `json:"numeric_answer,omitempty"`
+	TextAnswer      string   `json:"text_answer,omitempty"`
+}
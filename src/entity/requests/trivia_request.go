@@ -1,18 +1,60 @@
 package requests
 
 type CreateTriviaRequest struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	QuestionIDs []uint `json:"question_ids"`
-	UserIDs     []uint `json:"user_ids"`
+	Name              string `json:"name"`
+	Description       string `json:"description"`
+	ScoringStrategy   string `json:"scoring_strategy"`
+	TimeLimitSeconds  int    `json:"time_limit_seconds"`
+	MaxAttempts       int    `json:"max_attempts"`
+	CooldownSeconds   int    `json:"cooldown_seconds"`
+	RankingPolicy     string `json:"ranking_policy"`
+	RequireAllAnswers bool   `json:"require_all_answers"`
+	ShuffleQuestions  bool   `json:"shuffle_questions"`
+	ShuffleOptions    bool   `json:"shuffle_options"`
+	QuestionPoolSize  int    `json:"question_pool_size"`
+	QuestionIDs       []uint `json:"question_ids"`
+	UserIDs           []uint `json:"user_ids"`
 }
 
+// GenerateTriviaRequest creates a trivia like CreateTriviaRequest but picks
+// QuestionCount questions from the bank. DifficultyMix maps each difficulty
+// to its percentage of the questions and must add up to 100; when empty any
+// difficulty is used. CategoryIDs include their subcategories, and
+// ExcludeSeen skips questions any of the assigned users already answered.
+type GenerateTriviaRequest struct {
+	Name              string         `json:"name"`
+	Description       string         `json:"description"`
+	ScoringStrategy   string         `json:"scoring_strategy"`
+	TimeLimitSeconds  int            `json:"time_limit_seconds"`
+	MaxAttempts       int            `json:"max_attempts"`
+	CooldownSeconds   int            `json:"cooldown_seconds"`
+	RankingPolicy     string         `json:"ranking_policy"`
+	RequireAllAnswers bool           `json:"require_all_answers"`
+	ShuffleQuestions  bool           `json:"shuffle_questions"`
+	ShuffleOptions    bool           `json:"shuffle_options"`
+	QuestionPoolSize  int            `json:"question_pool_size"`
+	QuestionCount     int            `json:"question_count"`
+	DifficultyMix     map[string]int `json:"difficulty_mix"`
+	CategoryIDs       []uint         `json:"category_ids"`
+	ExcludeSeen       bool           `json:"exclude_seen"`
+	UserIDs           []uint         `json:"user_ids"`
+}
+
+// SubmitAnswersRequest.UserID is filled from the authenticated user and
+// IdempotencyKey from the Idempotency-Key header, not from the request body.
 type SubmitAnswersRequest struct {
-	UserID    uint            `json:"user_id"`
-	Responses []AnswerRequest `json:"responses"`
+	UserID         uint            `json:"-"`
+	IdempotencyKey string          `json:"-"`
+	Responses      []AnswerRequest `json:"responses"`
 }
 
+// AnswerRequest carries the answer in the shape of the question type:
+// SelectedOption for single choice and true/false, SelectedOptions for
+// multi-select, NumericAnswer for numeric and TextAnswer for free text.
 type AnswerRequest struct {
-	QuestionID     uint `json:"question_id"`
-	SelectedOption uint `json:"selected_option"`
+	QuestionID      uint     `json:"question_id"`
+	SelectedOption  uint     `json:"selected_option"`
+	SelectedOptions []uint   `json:"selected_options,omitempty"`
+	NumericAnswer   *float64 `json:"numeric_answer,omitempty"`
+	TextAnswer      string   `json:"text_answer,omitempty"`
 }
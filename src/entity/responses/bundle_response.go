@@ -0,0 +1,58 @@
+package responses
+
+import "time"
+
+// BundleVersion is the version of the ContentBundle format written by this
+// server; it changes whenever the format changes incompatibly.
+const BundleVersion = 1
+
+// ContentBundle is a portable export of questions and, optionally, the
+// trivia using them. It is also the body of the bundle imports.
+type ContentBundle struct {
+	Version    int              `json:"version"`
+	ExportedAt time.Time        `json:"exported_at"`
+	Questions  []BundleQuestion `json:"questions"`
+	Trivia     *BundleTrivia    `json:"trivia,omitempty"`
+}
+
+// BundleQuestion.Ref identifies the question inside its bundle; imports
+// create a new question for it. Categories are paths of category names from
+// the top-level category down, such as "Science/Physics".
+type BundleQuestion struct {
+	Ref              uint     `json:"ref"`
+	Question         string   `json:"question"`
+	Type             string   `json:"type"`
+	Difficulty       string   `json:"difficulty"`
+	Points           int      `json:"points"`
+	Options          []string `json:"options,omitempty"`
+	CorrectOption    int      `json:"correct_option"`
+	CorrectOptions   []int    `json:"correct_options,omitempty"`
+	PartialCredit    bool     `json:"partial_credit"`
+	NumericAnswer    *float64 `json:"numeric_answer,omitempty"`
+	Tolerance        float64  `json:"tolerance"`
+	AcceptedAnswers  []string `json:"accepted_answers,omitempty"`
+	TimeLimitSeconds int      `json:"time_limit_seconds"`
+	Explanation      string   `json:"explanation,omitempty"`
+	ReferenceURL     string   `json:"reference_url,omitempty"`
+	Categories       []string `json:"categories,omitempty"`
+	Tags             []string `json:"tags,omitempty"`
+}
+
+// BundleTrivia holds the settings of a trivia and the refs of its questions.
+// User assignments are not exported; UserIDs is only read by imports, which
+// assign those users of the target deployment.
+type BundleTrivia struct {
+	Name              string `json:"name"`
+	Description       string `json:"description"`
+	ScoringStrategy   string `json:"scoring_strategy"`
+	TimeLimitSeconds  int    `json:"time_limit_seconds"`
+	MaxAttempts       int    `json:"max_attempts"`
+	CooldownSeconds   int    `json:"cooldown_seconds"`
+	RankingPolicy     string `json:"ranking_policy"`
+	RequireAllAnswers bool   `json:"require_all_answers"`
+	ShuffleQuestions  bool   `json:"shuffle_questions"`
+	ShuffleOptions    bool   `json:"shuffle_options"`
+	QuestionPoolSize  int    `json:"question_pool_size"`
+	QuestionRefs      []uint `json:"question_refs"`
+	UserIDs           []uint `json:"user_ids,omitempty"`
+}
@@ -0,0 +1,13 @@
+package responses
+
+type CategoryResponse struct {
+	ID       uint               `json:"id"`
+	Name     string             `json:"name"`
+	ParentID *uint              `json:"parent_id,omitempty"`
+	Children []CategoryResponse `json:"children,omitempty"`
+}
+
+type TagResponse struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
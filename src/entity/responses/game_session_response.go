@@ -0,0 +1,38 @@
+package responses
+
+import "time"
+
+type GameSessionResponse struct {
+	ID                uint       `json:"id"`
+	TriviaID          uint       `json:"trivia_id"`
+	UserID            uint       `json:"user_id"`
+	Status            string     `json:"status"`
+	Score             int        `json:"score"`
+	CorrectAnswers    int        `json:"correct_answers"`
+	AnsweredQuestions int        `json:"answered_questions"`
+	TotalQuestions    int        `json:"total_questions"`
+	StartedAt         time.Time  `json:"started_at"`
+	ExpiresAt         *time.Time `json:"expires_at,omitempty"`
+	FinishedAt        *time.Time `json:"finished_at,omitempty"`
+}
+
+type SessionQuestionResponse struct {
+	SessionID      uint             `json:"session_id"`
+	Position       int              `json:"position"`
+	TotalQuestions int              `json:"total_questions"`
+	Question       QuestionResponse `json:"question"`
+	ServedAt       *time.Time       `json:"served_at"`
+	ExpiresAt      *time.Time       `json:"expires_at,omitempty"`
+}
+
+type SessionAnswerResponse struct {
+	QuestionID     uint                   `json:"question_id"`
+	IsCorrect      bool                   `json:"is_correct"`
+	Points         int                    `json:"points"`
+	ElapsedSeconds float64                `json:"elapsed_seconds"`
+	Explanation    string                 `json:"explanation,omitempty"`
+	ReferenceURL   string                 `json:"reference_url,omitempty"`
+	Finished       bool                   `json:"finished"`
+	Session        GameSessionResponse    `json:"session"`
+	Result         *SubmitAnswersResponse `json:"result,omitempty"`
+}
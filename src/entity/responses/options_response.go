@@ -1,6 +1,9 @@
 package responses
 
+// OptionResponse is an option of a question as shown to the client.
 type OptionResponse struct {
-	ID     uint   `json:"id"`
+	// Index is the position of the option in the list it is returned in,
+	// the value sent back as selected_option and reported as correct_option.
+	Index  int    `json:"index"`
 	Option string `json:"option"`
 }
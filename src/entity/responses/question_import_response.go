@@ -0,0 +1,22 @@
+package responses
+
+// QuestionImportResponse reports the outcome of every row of an import.
+// Committed is only true when every row was valid and DryRun was off.
+type QuestionImportResponse struct {
+	Format    string              `json:"format"`
+	DryRun    bool                `json:"dry_run"`
+	Committed bool                `json:"committed"`
+	Total     int                 `json:"total"`
+	Valid     int                 `json:"valid"`
+	Invalid   int                 `json:"invalid"`
+	Rows      []ImportRowResponse `json:"rows"`
+}
+
+// ImportRowResponse.Row is the line of a CSV file or the 1-based position of
+// the question in a JSON file.
+type ImportRowResponse struct {
+	Row        int    `json:"row"`
+	Question   string `json:"question,omitempty"`
+	QuestionID uint   `json:"question_id,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
@@ -1,9 +1,39 @@
 package responses
 
+// QuestionResponse is the player-facing projection of a question, it never
+// includes the correct answer. Numeric and free text questions have no
+// options.
 type QuestionResponse struct {
-	ID            uint             `json:"id"`
-	Question      string           `json:"question"`
-	CorrectOption uint             `json:"correct_option"`
-	Options       []OptionResponse `json:"options"`
-	Difficulty    string           `json:"difficulty"`
+	ID               uint               `json:"id"`
+	Question         string             `json:"question"`
+	Type             string             `json:"type"`
+	Options          []OptionResponse   `json:"options"`
+	Difficulty       string             `json:"difficulty"`
+	TimeLimitSeconds int                `json:"time_limit_seconds"`
+	Status           string             `json:"status"`
+	Categories       []CategoryResponse `json:"categories,omitempty"`
+	Tags             []string           `json:"tags,omitempty"`
+}
+
+// AuthorQuestionResponse is the author-facing projection of a question.
+type AuthorQuestionResponse struct {
+	ID               uint               `json:"id"`
+	Question         string             `json:"question"`
+	Type             string             `json:"type"`
+	CorrectOption    uint               `json:"correct_option"`
+	CorrectOptions   []uint             `json:"correct_options,omitempty"`
+	PartialCredit    bool               `json:"partial_credit"`
+	NumericAnswer    *float64           `json:"numeric_answer,omitempty"`
+	Tolerance        float64            `json:"tolerance"`
+	AcceptedAnswers  []string           `json:"accepted_answers,omitempty"`
+	Options          []OptionResponse   `json:"options"`
+	Difficulty       string             `json:"difficulty"`
+	Points           int                `json:"points"`
+	TimeLimitSeconds int                `json:"time_limit_seconds"`
+	Explanation      string             `json:"explanation,omitempty"`
+	ReferenceURL     string             `json:"reference_url,omitempty"`
+	Revision         int                `json:"revision"`
+	Status           string             `json:"status"`
+	Categories       []CategoryResponse `json:"categories,omitempty"`
+	Tags             []string           `json:"tags,omitempty"`
 }
@@ -0,0 +1,20 @@
+package responses
+
+import "time"
+
+// QuestionRevisionResponse is a question as it was in one of its revisions.
+// Changes lists the fields that differ from the previous revision and is
+// empty for the first one. Revisions do not record categories and tags.
+type QuestionRevisionResponse struct {
+	Revision  int                    `json:"revision"`
+	CreatedAt time.Time              `json:"created_at"`
+	Question  AuthorQuestionResponse `json:"question"`
+	Changes   []FieldChangeResponse  `json:"changes"`
+}
+
+// FieldChangeResponse.Field is named like the JSON fields of a question.
+type FieldChangeResponse struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
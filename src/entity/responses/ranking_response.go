@@ -0,0 +1,39 @@
+package responses
+
+import "time"
+
+type LeaderboardEntryResponse struct {
+	Rank           int    `json:"rank"`
+	UserID         uint   `json:"user_id"`
+	Name           string `json:"name"`
+	Score          int    `json:"score"`
+	CorrectAnswers int    `json:"correct_answers"`
+}
+
+type RankingResponse struct {
+	TriviaID uint                       `json:"trivia_id"`
+	Page     int                        `json:"page"`
+	PageSize int                        `json:"page_size"`
+	Total    int64                      `json:"total"`
+	Entries  []LeaderboardEntryResponse `json:"entries"`
+}
+
+type LeaderboardResponse struct {
+	Season     *SeasonResponse            `json:"season,omitempty"`
+	From       *time.Time                 `json:"from,omitempty"`
+	To         *time.Time                 `json:"to,omitempty"`
+	Department string                     `json:"department,omitempty"`
+	Difficulty string                     `json:"difficulty,omitempty"`
+	Page       int                        `json:"page"`
+	PageSize   int                        `json:"page_size"`
+	Total      int64                      `json:"total"`
+	Entries    []LeaderboardEntryResponse `json:"entries"`
+}
+
+type SeasonResponse struct {
+	ID       uint      `json:"id"`
+	Name     string    `json:"name"`
+	Slug     string    `json:"slug"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+}
@@ -0,0 +1,12 @@
+package responses
+
+import "time"
+
+type ReviewEventResponse struct {
+	ID         uint      `json:"id"`
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	Comment    string    `json:"comment,omitempty"`
+	UserID     uint      `json:"user_id"`
+	CreatedAt  time.Time `json:"created_at"`
+}
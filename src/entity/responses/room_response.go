@@ -0,0 +1,28 @@
+package responses
+
+type RoomResponse struct {
+	Code         string               `json:"code"`
+	TriviaID     uint                 `json:"trivia_id"`
+	HostID       uint                 `json:"host_id"`
+	Status       string               `json:"status"`
+	CurrentRound int                  `json:"current_round"`
+	TotalRounds  int                  `json:"total_rounds"`
+	Players      []RoomPlayerResponse `json:"players"`
+}
+
+type RoomPlayerResponse struct {
+	UserID    uint   `json:"user_id"`
+	Name      string `json:"name"`
+	Connected bool   `json:"connected"`
+}
+
+type RoomEvent struct {
+	Type string      `json:"type"`
+	Data interface{} `json:"data,omitempty"`
+}
+
+type RoomQuestionEvent struct {
+	Round       int                     `json:"round"`
+	TotalRounds int                     `json:"total_rounds"`
+	Question    SessionQuestionResponse `json:"question"`
+}
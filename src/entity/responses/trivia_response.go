@@ -1,11 +1,43 @@
 package responses
 
+import "time"
+
 type TriviaResponse struct {
-	ID          uint               `json:"id"`
-	Name        string             `json:"name"`
-	Description string             `json:"description"`
-	Questions   []QuestionResponse `json:"questions"`
-	Users       []UserResponse     `json:"users"`
+	ID                uint               `json:"id"`
+	Name              string             `json:"name"`
+	Description       string             `json:"description"`
+	ScoringStrategy   string             `json:"scoring_strategy"`
+	TimeLimitSeconds  int                `json:"time_limit_seconds"`
+	MaxAttempts       int                `json:"max_attempts"`
+	CooldownSeconds   int                `json:"cooldown_seconds"`
+	RankingPolicy     string             `json:"ranking_policy"`
+	RequireAllAnswers bool               `json:"require_all_answers"`
+	ShuffleQuestions  bool               `json:"shuffle_questions"`
+	ShuffleOptions    bool               `json:"shuffle_options"`
+	QuestionPoolSize  int                `json:"question_pool_size"`
+	Status            string             `json:"status"`
+	Questions         []QuestionResponse `json:"questions,omitempty"`
+	Users             []UserResponse     `json:"users"`
+}
+
+// AuthorTriviaResponse is the author-facing projection of a trivia, including
+// the correct answer of every question.
+type AuthorTriviaResponse struct {
+	ID                uint                     `json:"id"`
+	Name              string                   `json:"name"`
+	Description       string                   `json:"description"`
+	ScoringStrategy   string                   `json:"scoring_strategy"`
+	TimeLimitSeconds  int                      `json:"time_limit_seconds"`
+	MaxAttempts       int                      `json:"max_attempts"`
+	CooldownSeconds   int                      `json:"cooldown_seconds"`
+	RankingPolicy     string                   `json:"ranking_policy"`
+	RequireAllAnswers bool                     `json:"require_all_answers"`
+	ShuffleQuestions  bool                     `json:"shuffle_questions"`
+	ShuffleOptions    bool                     `json:"shuffle_options"`
+	QuestionPoolSize  int                      `json:"question_pool_size"`
+	Status            string                   `json:"status"`
+	Questions         []AuthorQuestionResponse `json:"questions"`
+	Users             []UserResponse           `json:"users"`
 }
 
 type PlayTriviaResponse struct {
@@ -16,17 +48,57 @@ type PlayTriviaResponse struct {
 }
 
 type SubmitAnswersResponse struct {
-	TriviaID       uint `json:"trivia_id"`
-	UserID         uint `json:"user_id"`
-	CorrectAnswers int  `json:"correct_answers"`
-	TotalQuestions int  `json:"total_questions"`
-	Score          int  `json:"score"`
+	TriviaID        uint                   `json:"trivia_id"`
+	UserID          uint                   `json:"user_id"`
+	ScoringStrategy string                 `json:"scoring_strategy"`
+	CorrectAnswers  int                    `json:"correct_answers"`
+	TotalQuestions  int                    `json:"total_questions"`
+	Score           int                    `json:"score"`
+	ElapsedSeconds  float64                `json:"elapsed_seconds"`
+	Answers         []AnswerResultResponse `json:"answers,omitempty"`
+}
+
+// AnswerResultResponse is the outcome of one submitted answer, revealing the
+// explanation of the question.
+type AnswerResultResponse struct {
+	QuestionID   uint   `json:"question_id"`
+	IsCorrect    bool   `json:"is_correct"`
+	Points       int    `json:"points"`
+	Explanation  string `json:"explanation,omitempty"`
+	ReferenceURL string `json:"reference_url,omitempty"`
 }
 
 type UserScoreResponse struct {
-	TriviaID       uint `json:"trivia_id"`
-	UserID         uint `json:"user_id"`
-	Score          int  `json:"score"`
-	CorrectAnswers int  `json:"correct_answers"`
-	TotalQuestions int  `json:"total_questions"`
+	ParticipationID uint                      `json:"participation_id"`
+	TriviaID        uint                      `json:"trivia_id"`
+	TriviaName      string                    `json:"trivia_name"`
+	UserID          uint                      `json:"user_id"`
+	Score           int                       `json:"score"`
+	CorrectAnswers  int                       `json:"correct_answers"`
+	TotalQuestions  int                       `json:"total_questions"`
+	StartedAt       *time.Time                `json:"started_at"`
+	FinishedAt      *time.Time                `json:"finished_at"`
+	Answers         []AnswerBreakdownResponse `json:"answers"`
+}
+
+// AnswerBreakdownResponse describes answers in the shape of the question
+// type; SelectedText and CorrectText hold a readable version of them. The
+// correct answer and explanation are left out while the player can still
+// retake the trivia.
+type AnswerBreakdownResponse struct {
+	QuestionID      uint     `json:"question_id"`
+	Question        string   `json:"question"`
+	Type            string   `json:"type"`
+	SelectedOption  uint     `json:"selected_option"`
+	SelectedOptions []uint   `json:"selected_options,omitempty"`
+	NumericAnswer   *float64 `json:"numeric_answer,omitempty"`
+	TextAnswer      string   `json:"text_answer,omitempty"`
+	SelectedText    string   `json:"selected_text"`
+	CorrectOption   *uint    `json:"correct_option,omitempty"`
+	CorrectText     string   `json:"correct_text,omitempty"`
+	IsCorrect       bool     `json:"is_correct"`
+	TimedOut        bool     `json:"timed_out"`
+	Points          int      `json:"points"`
+	Explanation     string   `json:"explanation,omitempty"`
+	ReferenceURL    string   `json:"reference_url,omitempty"`
 }
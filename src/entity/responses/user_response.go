@@ -1,7 +1,9 @@
 package responses
 
 type UserResponse struct {
-	ID    uint   `json:"id"`
-	Name  string `json:"name"`
-	Email string `json:"email"`
+	ID         uint     `json:"id"`
+	Name       string   `json:"name"`
+	Email      string   `json:"email"`
+	Department string   `json:"department"`
+	Roles      []string `json:"roles,omitempty"`
 }
@@ -0,0 +1,13 @@
+package responses
+
+type FieldError struct {
+	Field      string `json:"field"`
+	QuestionID uint   `json:"question_id,omitempty"`
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+}
+
+type ValidationErrorResponse struct {
+	Error   string       `json:"error"`
+	Details []FieldError `json:"details"`
+}
@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"errors"
+	authusecase "talana_prueba_tecnica/src/app/usecases/auth_usecase"
+	"talana_prueba_tecnica/src/entity/requests"
+	"talana_prueba_tecnica/src/entity/responses"
+	"talana_prueba_tecnica/src/infraestructure/middleware"
+	"talana_prueba_tecnica/src/shared"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type AuthHandler struct {
+	useCase authusecase.AuthUseCaseInterface
+}
+
+func NewAuthHandler(useCase authusecase.AuthUseCaseInterface) *AuthHandler {
+	return &AuthHandler{
+		useCase: useCase,
+	}
+}
+
+// @Summary Register a user
+// @Description Create a user account with a password
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param user body requests.RegisterUserRequest true "User details"
+// @Success 201 {object} responses.UserResponse "User registered"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 409 {object} map[string]interface{} "Email already registered"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /auth/register [post]
+func (h *AuthHandler) Register(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Register handler")
+
+	var req requests.RegisterUserRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		log.Errorf("Error parsing request: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+
+	result, err := h.useCase.Register(ctx.Context(), &req)
+	if err != nil {
+		log.Errorf("Error registering user: %v", err)
+		return ctx.Status(authErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	log.Info("User registered")
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{"data": result})
+}
+
+// @Summary Log in
+// @Description Exchange email and password for an access and a refresh token
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param credentials body requests.LoginRequest true "Credentials"
+// @Success 200 {object} responses.TokenResponse "Tokens"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 401 {object} map[string]interface{} "Invalid credentials"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /auth/login [post]
+func (h *AuthHandler) Login(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Login handler")
+
+	var req requests.LoginRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		log.Errorf("Error parsing request: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+
+	result, err := h.useCase.Login(ctx.Context(), &req)
+	if err != nil {
+		log.Errorf("Error logging in: %v", err)
+		return ctx.Status(authErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	log.Info("User logged in")
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"data": result})
+}
+
+// @Summary Refresh tokens
+// @Description Exchange a refresh token for a new access and refresh token
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param token body requests.RefreshTokenRequest true "Refresh token"
+// @Success 200 {object} responses.TokenResponse "Tokens"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 401 {object} map[string]interface{} "Invalid refresh token"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /auth/refresh [post]
+func (h *AuthHandler) Refresh(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Refresh token handler")
+
+	var req requests.RefreshTokenRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		log.Errorf("Error parsing request: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+
+	result, err := h.useCase.Refresh(ctx.Context(), &req)
+	if err != nil {
+		log.Errorf("Error refreshing tokens: %v", err)
+		return ctx.Status(authErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	log.Info("Tokens refreshed")
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"data": result})
+}
+
+// @Summary Get the current user
+// @Description Retrieve the user of the access token
+// @Tags Auth
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} responses.UserResponse "Current user"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Router /auth/me [get]
+func (h *AuthHandler) Me(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Current user handler")
+
+	user := middleware.CurrentUser(ctx)
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"data": responses.UserResponse{
+		ID:         user.ID,
+		Name:       user.Name,
+		Email:      user.Email,
+		Department: user.Department,
+		Roles:      user.RoleNames(),
+	}})
+}
+
+func authErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, authusecase.ErrInvalidCredentials), errors.Is(err, shared.ErrInvalidToken):
+		return fiber.StatusUnauthorized
+	case errors.Is(err, authusecase.ErrEmailTaken):
+		return fiber.StatusConflict
+	case errors.Is(err, shared.ErrPasswordTooShort):
+		return fiber.StatusBadRequest
+	}
+	return fiber.StatusInternalServerError
+}
@@ -0,0 +1,197 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+	categoryusecase "talana_prueba_tecnica/src/app/usecases/category_usecase"
+	"talana_prueba_tecnica/src/entity/requests"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type CategoryHandler struct {
+	useCase categoryusecase.CategoryUseCaseInterface
+}
+
+func NewCategoryHandler(useCase categoryusecase.CategoryUseCaseInterface) *CategoryHandler {
+	return &CategoryHandler{
+		useCase: useCase,
+	}
+}
+
+// @Summary Get all categories
+// @Description Retrieve the question category tree
+// @Tags Categories
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} []responses.CategoryResponse "Category tree"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /categories [get]
+func (h *CategoryHandler) GetAllCategories(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Get all categories handler")
+
+	result, err := h.useCase.FindAll(ctx.Context())
+	if err != nil {
+		log.Errorf("Error getting all categories: %v", err)
+		return ctx.Status(categoryErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	log.Info("Categories found")
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"data": result})
+}
+
+// @Summary Get category by ID
+// @Description Retrieve a category with its direct subcategories
+// @Tags Categories
+// @Security BearerAuth
+// @Param id path uint true "Category ID"
+// @Produce json
+// @Success 200 {object} responses.CategoryResponse "Category details"
+// @Failure 400 {object} map[string]interface{} "Invalid category ID"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 404 {object} map[string]interface{} "Category not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /categories/{id} [get]
+func (h *CategoryHandler) GetCategoryByID(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Get category handler")
+
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		log.Errorf("Invalid category ID: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid category ID"})
+	}
+
+	result, err := h.useCase.FindByID(ctx.Context(), uint(id))
+	if err != nil {
+		log.Errorf("Error getting category: %v", err)
+		return ctx.Status(categoryErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	log.Info("Category found")
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"data": result})
+}
+
+// @Summary Create a category
+// @Description Create a question category, optionally nested under a parent category
+// @Tags Categories
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param category body requests.CreateCategoryRequest true "Category details"
+// @Success 201 {object} responses.CategoryResponse "Category created"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /categories [post]
+func (h *CategoryHandler) CreateCategory(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Create category handler")
+
+	var req requests.CreateCategoryRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		log.Errorf("Error parsing request: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+
+	result, err := h.useCase.CreateCategory(ctx.Context(), &req)
+	if err != nil {
+		log.Errorf("Error creating category: %v", err)
+		return ctx.Status(categoryErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	log.Info("Category created")
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{"data": result})
+}
+
+// @Summary Update a category
+// @Description Rename a category or move it under another parent
+// @Tags Categories
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path uint true "Category ID"
+// @Param category body requests.CreateCategoryRequest true "Category details"
+// @Success 200 {object} responses.CategoryResponse "Category updated"
+// @Failure 400 {object} map[string]interface{} "Invalid request, ID or parent"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "Category not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /categories/{id} [put]
+func (h *CategoryHandler) UpdateCategory(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Update category handler")
+
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		log.Errorf("Invalid category ID: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid category ID"})
+	}
+
+	var req requests.CreateCategoryRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		log.Errorf("Error parsing request: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+
+	result, err := h.useCase.UpdateCategory(ctx.Context(), uint(id), &req)
+	if err != nil {
+		log.Errorf("Error updating category: %v", err)
+		return ctx.Status(categoryErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	log.Info("Category updated")
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"data": result})
+}
+
+// @Summary Delete a category
+// @Description Delete a category without subcategories, unlinking its questions
+// @Tags Categories
+// @Security BearerAuth
+// @Param id path uint true "Category ID"
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Category deleted"
+// @Failure 400 {object} map[string]interface{} "Invalid category ID"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "Category not found"
+// @Failure 409 {object} map[string]interface{} "Category has subcategories"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /categories/{id} [delete]
+func (h *CategoryHandler) DeleteCategory(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Delete category handler")
+
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		log.Errorf("Invalid category ID: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid category ID"})
+	}
+
+	if err := h.useCase.DeleteCategory(ctx.Context(), uint(id)); err != nil {
+		log.Errorf("Error deleting category: %v", err)
+		return ctx.Status(categoryErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	log.Info("Category deleted")
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Category deleted"})
+}
+
+func categoryErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, categoryusecase.ErrCategoryNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, categoryusecase.ErrInvalidCategory),
+		errors.Is(err, categoryusecase.ErrParentNotFound),
+		errors.Is(err, categoryusecase.ErrCategoryCycle):
+		return fiber.StatusBadRequest
+	case errors.Is(err, categoryusecase.ErrCategoryHasChildren):
+		return fiber.StatusConflict
+	}
+	return fiber.StatusInternalServerError
+}
@@ -1,11 +1,14 @@
 package handlers
 
 import (
+	"errors"
 	"github.com/gofiber/fiber/v2"
 	"github.com/sirupsen/logrus"
 	"strconv"
 	gameusecase "talana_prueba_tecnica/src/app/usecases/game_usecase"
 	"talana_prueba_tecnica/src/entity/requests"
+	"talana_prueba_tecnica/src/entity/responses"
+	"talana_prueba_tecnica/src/infraestructure/middleware"
 )
 
 type GameHandler struct {
@@ -19,12 +22,16 @@ func NewGameHandler(useCase gameusecase.GameUseCaseInterface) *GameHandler {
 }
 
 // @Summary Get questions for a trivia
-// @Description Retrieve all questions for a specific trivia
+// @Description Preview the questions of a published trivia as they would be served to the current user, shuffled and sampled per attempt when the trivia enables it. Players get their questions one at a time through game sessions
 // @Tags Games
+// @Security BearerAuth
 // @Param id path uint true "Trivia ID"
 // @Produce json
 // @Success 200 {object} []responses.QuestionResponse "Questions for the trivia"
 // @Failure 400 {object} map[string]interface{} "Invalid trivia ID"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "Trivia not published"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /games/trivias/{id}/questions [get]
 func (h *GameHandler) GetQuestionsForTrivia(ctx *fiber.Ctx) error {
@@ -37,10 +44,10 @@ func (h *GameHandler) GetQuestionsForTrivia(ctx *fiber.Ctx) error {
 		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid trivia ID"})
 	}
 
-	questions, err := h.useCase.GetQuestionsForTrivia(ctx.Context(), uint(id))
+	questions, err := h.useCase.GetQuestionsForTrivia(ctx.Context(), uint(id), middleware.CurrentUser(ctx).ID)
 	if err != nil {
 		log.Errorf("Error getting questions for trivia: %v", err)
-		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return ctx.Status(gameErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
 	}
 
 	log.Info("Questions for trivia retrieved successfully")
@@ -50,12 +57,20 @@ func (h *GameHandler) GetQuestionsForTrivia(ctx *fiber.Ctx) error {
 // @Summary Submit answers for a trivia
 // @Description Submit answers for a specific trivia and calculate the user's score
 // @Tags Games
+// @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param id path uint true "Trivia ID"
 // @Param answers body requests.SubmitAnswersRequest true "User answers"
+// @Param Idempotency-Key header string false "Key making retries return the original result"
 // @Success 200 {object} responses.SubmitAnswersResponse "User score and details"
 // @Failure 400 {object} map[string]interface{} "Invalid request or trivia ID"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Not a player or not assigned to the trivia"
+// @Failure 404 {object} map[string]interface{} "Trivia not published"
+// @Failure 409 {object} map[string]interface{} "Trivia is time limited or no attempts left"
+// @Failure 429 {object} map[string]interface{} "Attempt cool-down still running"
+// @Failure 422 {object} responses.ValidationErrorResponse "Invalid answers"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /games/trivias/{id}/answers [post]
 func (h *GameHandler) SubmitAnswers(ctx *fiber.Ctx) error {
@@ -73,13 +88,180 @@ func (h *GameHandler) SubmitAnswers(ctx *fiber.Ctx) error {
 		log.Errorf("Error parsing request: %v", err)
 		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
 	}
+	req.UserID = middleware.CurrentUser(ctx).ID
+	req.IdempotencyKey = ctx.Get(middleware.IdempotencyKeyHeader)
 
 	response, err := h.useCase.SubmitAnswers(ctx.Context(), uint(id), &req)
 	if err != nil {
 		log.Errorf("Error submitting answers: %v", err)
-		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return gameError(ctx, err)
 	}
 
 	log.Info("Answers submitted successfully")
 	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"data": response})
 }
+
+// @Summary Start a game session
+// @Description Start (or resume) a session to play a trivia one question at a time
+// @Tags Games
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path uint true "Trivia ID"
+// @Success 201 {object} responses.GameSessionResponse "Session state"
+// @Failure 400 {object} map[string]interface{} "Invalid request or trivia ID"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Not a player or not assigned to the trivia"
+// @Failure 404 {object} map[string]interface{} "Trivia not published"
+// @Failure 409 {object} map[string]interface{} "No attempts left"
+// @Failure 429 {object} map[string]interface{} "Attempt cool-down still running"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /games/trivias/{id}/sessions [post]
+func (h *GameHandler) StartSession(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Start session handler")
+
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		log.Errorf("Invalid trivia ID: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid trivia ID"})
+	}
+
+	req := requests.StartSessionRequest{UserID: middleware.CurrentUser(ctx).ID}
+	response, err := h.useCase.StartSession(ctx.Context(), uint(id), &req)
+	if err != nil {
+		log.Errorf("Error starting session: %v", err)
+		return ctx.Status(gameErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	log.Info("Session started successfully")
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{"data": response})
+}
+
+// @Summary Get the next question of a session
+// @Description Serve the next unanswered question of a game session
+// @Tags Games
+// @Security BearerAuth
+// @Param id path uint true "Trivia ID"
+// @Param sid path uint true "Session ID"
+// @Produce json
+// @Success 200 {object} responses.SessionQuestionResponse "Next question"
+// @Failure 400 {object} map[string]interface{} "Invalid trivia or session ID"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "Session not found or trivia not published"
+// @Failure 409 {object} map[string]interface{} "Session already finished or out of time"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /games/trivias/{id}/sessions/{sid}/next [get]
+func (h *GameHandler) NextQuestion(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Next question handler")
+
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		log.Errorf("Invalid trivia ID: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid trivia ID"})
+	}
+
+	sid, err := strconv.ParseUint(ctx.Params("sid"), 10, 64)
+	if err != nil {
+		log.Errorf("Invalid session ID: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid session ID"})
+	}
+
+	response, err := h.useCase.NextQuestion(ctx.Context(), uint(id), uint(sid))
+	if err != nil {
+		log.Errorf("Error getting next question: %v", err)
+		return ctx.Status(gameErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	log.Info("Next question retrieved successfully")
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"data": response})
+}
+
+// @Summary Answer the current question of a session
+// @Description Record the answer to the question currently served by a game session
+// @Tags Games
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path uint true "Trivia ID"
+// @Param sid path uint true "Session ID"
+// @Param answer body requests.SessionAnswerRequest true "Answer"
+// @Success 200 {object} responses.SessionAnswerResponse "Answer result and session state"
+// @Failure 400 {object} map[string]interface{} "Invalid request, trivia or session ID"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "Session not found or trivia not published"
+// @Failure 409 {object} map[string]interface{} "Session finished, question not current or answered too late"
+// @Failure 422 {object} responses.ValidationErrorResponse "Invalid answers"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /games/trivias/{id}/sessions/{sid}/answer [post]
+func (h *GameHandler) AnswerQuestion(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Answer question handler")
+
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		log.Errorf("Invalid trivia ID: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid trivia ID"})
+	}
+
+	sid, err := strconv.ParseUint(ctx.Params("sid"), 10, 64)
+	if err != nil {
+		log.Errorf("Invalid session ID: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid session ID"})
+	}
+
+	var req requests.SessionAnswerRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		log.Errorf("Error parsing request: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+
+	response, err := h.useCase.AnswerQuestion(ctx.Context(), uint(id), uint(sid), &req)
+	if err != nil {
+		log.Errorf("Error answering question: %v", err)
+		return gameError(ctx, err)
+	}
+
+	log.Info("Answer recorded successfully")
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"data": response})
+}
+
+// gameError writes err with its status, detailing every field of answer
+// validation errors.
+func gameError(ctx *fiber.Ctx, err error) error {
+	var validationErr *gameusecase.AnswerValidationError
+	if errors.As(err, &validationErr) {
+		return ctx.Status(fiber.StatusUnprocessableEntity).JSON(responses.ValidationErrorResponse{
+			Error:   err.Error(),
+			Details: validationErr.Fields,
+		})
+	}
+	return ctx.Status(gameErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+}
+
+func gameErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, gameusecase.ErrSessionNotFound),
+		errors.Is(err, gameusecase.ErrTriviaNotPublished):
+		return fiber.StatusNotFound
+	case errors.Is(err, gameusecase.ErrNotAssigned):
+		return fiber.StatusForbidden
+	case errors.Is(err, gameusecase.ErrAttemptCooldown):
+		return fiber.StatusTooManyRequests
+	case errors.Is(err, gameusecase.ErrIdempotencyKeyReused):
+		return fiber.StatusUnprocessableEntity
+	case errors.Is(err, gameusecase.ErrSessionFinished),
+		errors.Is(err, gameusecase.ErrQuestionNotServed),
+		errors.Is(err, gameusecase.ErrUnexpectedQuestion),
+		errors.Is(err, gameusecase.ErrAnswerTooLate),
+		errors.Is(err, gameusecase.ErrAlreadyAnswered),
+		errors.Is(err, gameusecase.ErrTimeLimitExceeded),
+		errors.Is(err, gameusecase.ErrTimedTriviaRequiresSession),
+		errors.Is(err, gameusecase.ErrMaxAttemptsReached):
+		return fiber.StatusConflict
+	}
+	return fiber.StatusInternalServerError
+}
@@ -0,0 +1,194 @@
+package handlers
+
+import (
+	"errors"
+	leaderboardusecase "talana_prueba_tecnica/src/app/usecases/leaderboard_usecase"
+	"talana_prueba_tecnica/src/entity/requests"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type LeaderboardHandler struct {
+	useCase leaderboardusecase.LeaderboardUseCaseInterface
+}
+
+func NewLeaderboardHandler(useCase leaderboardusecase.LeaderboardUseCaseInterface) *LeaderboardHandler {
+	return &LeaderboardHandler{
+		useCase: useCase,
+	}
+}
+
+// @Summary Get the global leaderboard
+// @Description Retrieve the leaderboard aggregated across all trivias
+// @Tags Leaderboards
+// @Security BearerAuth
+// @Param from query string false "Only participations finished from this date (YYYY-MM-DD or RFC3339)"
+// @Param to query string false "Only participations finished before this date (YYYY-MM-DD or RFC3339)"
+// @Param department query string false "User department"
+// @Param difficulty query string false "Question difficulty (facil, medio, dificil)"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Produce json
+// @Success 200 {object} responses.LeaderboardResponse "Global leaderboard"
+// @Failure 400 {object} map[string]interface{} "Invalid filters"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /leaderboards/global [get]
+func (h *LeaderboardHandler) GetGlobalLeaderboard(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Get global leaderboard handler")
+
+	query, err := parseLeaderboardQuery(ctx)
+	if err != nil {
+		log.Errorf("Invalid leaderboard filters: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	result, err := h.useCase.GetGlobalLeaderboard(ctx.Context(), query)
+	if err != nil {
+		log.Errorf("Error getting global leaderboard: %v", err)
+		return ctx.Status(leaderboardErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	log.Info("Global leaderboard found")
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"data": result})
+}
+
+// @Summary Get a season leaderboard
+// @Description Retrieve the leaderboard aggregated across all trivias played during a season
+// @Tags Leaderboards
+// @Security BearerAuth
+// @Param season path string true "Season slug"
+// @Param department query string false "User department"
+// @Param difficulty query string false "Question difficulty (facil, medio, dificil)"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Produce json
+// @Success 200 {object} responses.LeaderboardResponse "Season leaderboard"
+// @Failure 400 {object} map[string]interface{} "Invalid filters"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 404 {object} map[string]interface{} "Season not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /leaderboards/seasons/{season} [get]
+func (h *LeaderboardHandler) GetSeasonLeaderboard(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Get season leaderboard handler")
+
+	query, err := parseLeaderboardQuery(ctx)
+	if err != nil {
+		log.Errorf("Invalid leaderboard filters: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	result, err := h.useCase.GetSeasonLeaderboard(ctx.Context(), ctx.Params("season"), query)
+	if err != nil {
+		log.Errorf("Error getting season leaderboard: %v", err)
+		return ctx.Status(leaderboardErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	log.Info("Season leaderboard found")
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"data": result})
+}
+
+// @Summary Get all seasons
+// @Description Retrieve the list of leaderboard seasons
+// @Tags Leaderboards
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} []responses.SeasonResponse "List of seasons"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /leaderboards/seasons [get]
+func (h *LeaderboardHandler) GetAllSeasons(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Get all seasons handler")
+
+	result, err := h.useCase.FindAllSeasons(ctx.Context())
+	if err != nil {
+		log.Errorf("Error getting all seasons: %v", err)
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	log.Info("Seasons found")
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"data": result})
+}
+
+// @Summary Create a season
+// @Description Define a new leaderboard season as a date range
+// @Tags Leaderboards
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param season body requests.CreateSeasonRequest true "Season details"
+// @Success 201 {object} responses.SeasonResponse "Season created"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /leaderboards/seasons [post]
+func (h *LeaderboardHandler) CreateSeason(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Create season handler")
+
+	var req requests.CreateSeasonRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		log.Errorf("Error parsing request: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+
+	result, err := h.useCase.CreateSeason(ctx.Context(), &req)
+	if err != nil {
+		log.Errorf("Error creating season: %v", err)
+		return ctx.Status(leaderboardErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	log.Info("Season created")
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{"data": result})
+}
+
+func parseLeaderboardQuery(ctx *fiber.Ctx) (*requests.LeaderboardQuery, error) {
+	from, err := parseDateQuery(ctx, "from")
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseDateQuery(ctx, "to")
+	if err != nil {
+		return nil, err
+	}
+
+	return &requests.LeaderboardQuery{
+		From:       from,
+		To:         to,
+		Department: ctx.Query("department"),
+		Difficulty: ctx.Query("difficulty"),
+		Page:       ctx.QueryInt("page", 1),
+		PageSize:   ctx.QueryInt("page_size", 20),
+	}, nil
+}
+
+func parseDateQuery(ctx *fiber.Ctx, key string) (*time.Time, error) {
+	value := ctx.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if date, err := time.Parse(layout, value); err == nil {
+			return &date, nil
+		}
+	}
+	return nil, errors.New("invalid " + key + " date")
+}
+
+func leaderboardErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, leaderboardusecase.ErrSeasonNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, leaderboardusecase.ErrInvalidSeason),
+		errors.Is(err, leaderboardusecase.ErrInvalidDifficulty),
+		errors.Is(err, leaderboardusecase.ErrInvalidDateRange):
+		return fiber.StatusBadRequest
+	}
+	return fiber.StatusInternalServerError
+}
@@ -1,9 +1,17 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
 	"strconv"
 	questionsusecase "talana_prueba_tecnica/src/app/usecases/questions_usecase"
+	reviewusecase "talana_prueba_tecnica/src/app/usecases/review_usecase"
+	"talana_prueba_tecnica/src/entity/models"
 	"talana_prueba_tecnica/src/entity/requests"
+	"talana_prueba_tecnica/src/entity/responses"
+	"talana_prueba_tecnica/src/infraestructure/middleware"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/sirupsen/logrus"
@@ -20,21 +28,36 @@ func NewQuestionHandler(useCase questionsusecase.QuestionUseCaseInterface) *Ques
 }
 
 // @Summary Get all questions
-// @Description Retrieve a list of all available questions
+// @Description Retrieve a list of all available questions, optionally filtered by category, tag, difficulty and status. Players only see published questions.
 // @Tags Questions
+// @Security BearerAuth
 // @Produce json
+// @Param category query uint false "Category ID, including its subcategories"
+// @Param tag query string false "Tag name"
+// @Param difficulty query string false "Question difficulty (facil, medio, dificil)"
+// @Param status query string false "Question status, authors only" Enums(draft, in_review, published, archived)
 // @Success 200 {object} []responses.QuestionResponse "List of questions"
+// @Failure 400 {object} map[string]interface{} "Invalid filter"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /questions [get]
-
 func (h *QuestionHandler) GetAllQuestions(ctx *fiber.Ctx) error {
 	log := logrus.WithContext(ctx.Context())
 	log.Info("Get all questions handler")
 
-	result, err := h.useCase.FindAll(ctx.Context())
+	query, err := parseQuestionQuery(ctx)
+	if err != nil {
+		log.Errorf("Invalid question filter: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	if !canSeeDrafts(ctx) {
+		query.Status = models.StatusPublished
+	}
+
+	result, err := h.useCase.FindAll(ctx.Context(), query)
 	if err != nil {
 		log.Errorf("Error getting all questions: %v", err)
-		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return ctx.Status(questionErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
 	}
 
 	log.Info("Questions found")
@@ -42,12 +65,15 @@ func (h *QuestionHandler) GetAllQuestions(ctx *fiber.Ctx) error {
 }
 
 // @Summary Get question by ID
-// @Description Retrieve details of a specific question by its ID
+// @Description Retrieve details of a specific question by its ID. Players only see published questions.
 // @Tags Questions
+// @Security BearerAuth
 // @Param id path uint true "Question ID"
 // @Produce json
 // @Success 200 {object} responses.QuestionResponse "Question details"
 // @Failure 400 {object} map[string]interface{} "Invalid question ID"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 404 {object} map[string]interface{} "Question not found"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /questions/{id} [get]
 func (h *QuestionHandler) GetQuestionByID(ctx *fiber.Ctx) error {
@@ -64,10 +90,10 @@ func (h *QuestionHandler) GetQuestionByID(ctx *fiber.Ctx) error {
 
 	newId := uint(transformId)
 
-	result, err := h.useCase.FindByID(ctx.Context(), newId)
+	result, err := h.useCase.FindByID(ctx.Context(), newId, !canSeeDrafts(ctx))
 	if err != nil {
 		log.Error(err)
-		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return ctx.Status(questionErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
 	}
 
 	log.Info("Question found")
@@ -79,11 +105,17 @@ func (h *QuestionHandler) GetQuestionByID(ctx *fiber.Ctx) error {
 // @Summary Create a new question
 // @Description Add a new question to the system
 // @Tags Questions
+// @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param question body requests.CreateQuestionRequest true "Question details"
+// @Param Idempotency-Key header string false "Key making retries return the original response"
 // @Success 201 {object} map[string]interface{} "Question created"
 // @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 409 {object} map[string]interface{} "Request with this Idempotency-Key still in progress"
+// @Failure 422 {object} map[string]interface{} "Idempotency-Key used for a different request"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /questions [post]
 func (h *QuestionHandler) CreateQuestion(ctx *fiber.Ctx) error {
@@ -99,22 +131,121 @@ func (h *QuestionHandler) CreateQuestion(ctx *fiber.Ctx) error {
 	err := h.useCase.CreateQuestion(ctx.Context(), &req)
 	if err != nil {
 		log.Error(err)
-		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return ctx.Status(questionErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
 	}
 
 	log.Info("Question created")
 	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{"message": "Question created"})
 }
 
+// @Summary Import questions
+// @Description Create many questions at once from a CSV file, a JSON array of questions, a bundle from GET /questions/export or an Open Trivia DB response, sent as the request body or as the "file" form field. CSV files name their columns in the first line like the JSON fields of a question, separating list values with "|". Nothing is saved unless every row is valid; dry runs only report.
+// @Tags Questions
+// @Security BearerAuth
+// @Accept json,text/csv,mpfd
+// @Produce json
+// @Param format query string false "File format, detected when omitted" Enums(csv, json, bundle, opentdb)
+// @Param dry_run query bool false "Validate without saving"
+// @Param file formData file false "File to import"
+// @Param Idempotency-Key header string false "Key making retries return the original response"
+// @Success 200 {object} responses.QuestionImportResponse "Dry run report"
+// @Success 201 {object} responses.QuestionImportResponse "Questions imported"
+// @Failure 400 {object} map[string]interface{} "Unreadable file or unknown format"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 409 {object} map[string]interface{} "Request with this Idempotency-Key still in progress"
+// @Failure 422 {object} responses.QuestionImportResponse "Some rows are invalid, or Idempotency-Key used for a different request"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /questions/import [post]
+func (h *QuestionHandler) ImportQuestions(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Import questions handler")
+
+	req := requests.ImportQuestionsRequest{
+		Format:      ctx.Query("format"),
+		DryRun:      ctx.QueryBool("dry_run"),
+		ContentType: ctx.Get(fiber.HeaderContentType),
+		Data:        ctx.Body(),
+	}
+	if file, err := ctx.FormFile("file"); err == nil {
+		data, err := readFormFile(file)
+		if err != nil {
+			log.Errorf("Error reading import file: %v", err)
+			return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid file"})
+		}
+		req.ContentType = file.Header.Get(fiber.HeaderContentType)
+		req.Data = data
+	}
+
+	result, err := h.useCase.ImportQuestions(ctx.Context(), &req)
+	if errors.Is(err, questionsusecase.ErrImportRejected) {
+		log.Error(err)
+		return ctx.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{"error": err.Error(), "data": result})
+	}
+	if err != nil {
+		log.Error(err)
+		return ctx.Status(questionErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	if !result.Committed {
+		log.Info("Questions import validated")
+		return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"data": result})
+	}
+
+	log.Info("Questions imported")
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{"data": result})
+}
+
+// @Summary Export questions
+// @Description Download the questions matching the filters as a versioned JSON bundle, or as a CSV file in the format read by the question import
+// @Tags Authoring
+// @Security BearerAuth
+// @Produce json,text/csv
+// @Param format query string false "File format" Enums(json, csv) default(json)
+// @Param category query uint false "Category ID, including its subcategories"
+// @Param tag query string false "Tag name"
+// @Param difficulty query string false "Question difficulty (facil, medio, dificil)"
+// @Param status query string false "Question status" Enums(draft, in_review, published, archived)
+// @Success 200 {object} responses.ContentBundle "Question bundle"
+// @Failure 400 {object} map[string]interface{} "Invalid filter or format"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /questions/export [get]
+func (h *QuestionHandler) ExportQuestions(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Export questions handler")
+
+	query, err := parseQuestionQuery(ctx)
+	if err != nil {
+		log.Errorf("Invalid question filter: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	result, err := h.useCase.ExportQuestions(ctx.Context(), query)
+	if err != nil {
+		log.Errorf("Error exporting questions: %v", err)
+		return ctx.Status(questionErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	log.Info("Questions exported")
+	return sendBundle(ctx, result, "questions")
+}
+
 // @Summary Update a question
-// @Description Update the details of an existing question
+// @Description Update the details of an existing question. Only drafts can be edited.
 // @Tags Questions
+// @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param id path uint true "Question ID"
 // @Param question body requests.CreateQuestionRequest true "Updated question details"
 // @Success 200 {object} map[string]interface{} "Question updated"
 // @Failure 400 {object} map[string]interface{} "Invalid request or question ID"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "Question not found"
+// @Failure 409 {object} map[string]interface{} "Question is not a draft"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /questions/{id} [put]
 func (h *QuestionHandler) UpdateQuestion(ctx *fiber.Ctx) error {
@@ -140,7 +271,7 @@ func (h *QuestionHandler) UpdateQuestion(ctx *fiber.Ctx) error {
 	err = h.useCase.UpdateQuestion(ctx.Context(), &req, newId)
 	if err != nil {
 		log.Error(err)
-		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return ctx.Status(questionErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
 	}
 
 	log.Info("Question updated")
@@ -148,12 +279,16 @@ func (h *QuestionHandler) UpdateQuestion(ctx *fiber.Ctx) error {
 }
 
 // @Summary Delete a question
-// @Description Remove a question from the system
+// @Description Remove a question from the system. It is kept, together with the answers given to it, until purged, so admins can restore it.
 // @Tags Questions
+// @Security BearerAuth
 // @Param id path uint true "Question ID"
 // @Produce json
 // @Success 200 {object} map[string]interface{} "Question deleted"
 // @Failure 400 {object} map[string]interface{} "Invalid question ID"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "Question not found"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /questions/{id} [delete]
 func (h *QuestionHandler) DeleteQuestion(ctx *fiber.Ctx) error {
@@ -173,19 +308,86 @@ func (h *QuestionHandler) DeleteQuestion(ctx *fiber.Ctx) error {
 	err = h.useCase.DeleteQuestion(ctx.Context(), newId)
 	if err != nil {
 		log.Error(err)
-		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return ctx.Status(questionErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
 	}
 
 	log.Info("Question deleted")
 	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Question deleted"})
 }
 
+// @Summary Restore a question
+// @Description Bring back a deleted question with its options, taxonomy and trivias
+// @Tags Questions
+// @Security BearerAuth
+// @Param id path uint true "Question ID"
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Question restored"
+// @Failure 400 {object} map[string]interface{} "Invalid question ID"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "Deleted question not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /questions/{id}/restore [post]
+func (h *QuestionHandler) RestoreQuestion(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Restore question handler")
+
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		log.Errorf("Error parsing id: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid id"})
+	}
+
+	if err := h.useCase.RestoreQuestion(ctx.Context(), uint(id)); err != nil {
+		log.Error(err)
+		return ctx.Status(questionErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	log.Info("Question restored")
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Question restored"})
+}
+
+// @Summary Purge a question
+// @Description Permanently remove a deleted question with its revisions. Questions served in a game keep their answers and cannot be purged.
+// @Tags Questions
+// @Security BearerAuth
+// @Param id path uint true "Question ID"
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Question purged"
+// @Failure 400 {object} map[string]interface{} "Invalid question ID"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "Deleted question not found"
+// @Failure 409 {object} map[string]interface{} "Question was played"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /questions/{id}/purge [delete]
+func (h *QuestionHandler) PurgeQuestion(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Purge question handler")
+
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		log.Errorf("Error parsing id: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid id"})
+	}
+
+	if err := h.useCase.PurgeQuestion(ctx.Context(), uint(id)); err != nil {
+		log.Error(err)
+		return ctx.Status(questionErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	log.Info("Question purged")
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Question purged"})
+}
+
 // @Summary Full text search for questions
-// @Description Search for questions using a text query
+// @Description Search for questions using a text query. Players only see published questions.
 // @Tags Questions
+// @Security BearerAuth
 // @Param search query string true "Search query"
 // @Produce json
 // @Success 200 {object} responses.QuestionResponse "Search results"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /questions/search [get]
 func (h *QuestionHandler) FullTextSearch(ctx *fiber.Ctx) error {
@@ -194,7 +396,7 @@ func (h *QuestionHandler) FullTextSearch(ctx *fiber.Ctx) error {
 
 	search := ctx.Query("search")
 
-	result, err := h.useCase.FullTextSearch(ctx.Context(), search)
+	result, err := h.useCase.FullTextSearch(ctx.Context(), search, !canSeeDrafts(ctx))
 	if err != nil {
 		log.Error(err)
 		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
@@ -203,3 +405,275 @@ func (h *QuestionHandler) FullTextSearch(ctx *fiber.Ctx) error {
 	log.Info("Questions found")
 	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"data": result})
 }
+
+// @Summary Get all questions for authors
+// @Description Retrieve all questions including their correct answer, optionally filtered by category, tag, difficulty and status
+// @Tags Authoring
+// @Security BearerAuth
+// @Produce json
+// @Param category query uint false "Category ID, including its subcategories"
+// @Param tag query string false "Tag name"
+// @Param difficulty query string false "Question difficulty (facil, medio, dificil)"
+// @Param status query string false "Question status" Enums(draft, in_review, published, archived)
+// @Success 200 {object} []responses.AuthorQuestionResponse "List of questions"
+// @Failure 400 {object} map[string]interface{} "Invalid filter"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /author/questions [get]
+func (h *QuestionHandler) GetAllQuestionsForAuthor(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Get all questions for author handler")
+
+	query, err := parseQuestionQuery(ctx)
+	if err != nil {
+		log.Errorf("Invalid question filter: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	result, err := h.useCase.FindAllForAuthor(ctx.Context(), query)
+	if err != nil {
+		log.Errorf("Error getting all questions: %v", err)
+		return ctx.Status(questionErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	log.Info("Questions found")
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"data": result})
+}
+
+// @Summary Get question revisions
+// @Description Retrieve every revision of a question, oldest first, with the fields each one changed. Answers are graded against, and reviewed with, the revision current when they were given.
+// @Tags Authoring
+// @Security BearerAuth
+// @Param id path uint true "Question ID"
+// @Produce json
+// @Success 200 {object} []responses.QuestionRevisionResponse "Question revisions"
+// @Failure 400 {object} map[string]interface{} "Invalid question ID"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "Question not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /questions/{id}/revisions [get]
+func (h *QuestionHandler) GetQuestionRevisions(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Get question revisions handler")
+
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		log.Errorf("Error parsing id: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid id"})
+	}
+
+	result, err := h.useCase.QuestionRevisions(ctx.Context(), uint(id))
+	if err != nil {
+		log.Error(err)
+		return ctx.Status(questionErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	log.Info("Question revisions found")
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"data": result})
+}
+
+// @Summary Change question status
+// @Description Move a question through the editorial workflow: draft → in_review → published → archived, and archived back to draft. Only reviewers (admins) can publish a question in review or send it back to draft, which requires a comment.
+// @Tags Authoring
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path uint true "Question ID"
+// @Param status body requests.StatusChangeRequest true "New status and reviewer comment"
+// @Success 200 {object} responses.AuthorQuestionResponse "Question with its new status"
+// @Failure 400 {object} map[string]interface{} "Invalid request, status or missing comment"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions or not a reviewer"
+// @Failure 404 {object} map[string]interface{} "Question not found"
+// @Failure 409 {object} map[string]interface{} "Status change not allowed"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /questions/{id}/status [post]
+func (h *QuestionHandler) ChangeQuestionStatus(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Change question status handler")
+
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		log.Errorf("Error parsing id: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid id"})
+	}
+
+	req, err := parseStatusChange(ctx)
+	if err != nil {
+		log.Errorf("Error parsing request: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+
+	result, err := h.useCase.ChangeQuestionStatus(ctx.Context(), uint(id), &req)
+	if err != nil {
+		log.Error(err)
+		return ctx.Status(questionErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	log.Info("Question status changed")
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"data": result})
+}
+
+// @Summary Get question status history
+// @Description Retrieve the status changes of a question with the reviewer comments, oldest first
+// @Tags Authoring
+// @Security BearerAuth
+// @Param id path uint true "Question ID"
+// @Produce json
+// @Success 200 {object} []responses.ReviewEventResponse "Status changes"
+// @Failure 400 {object} map[string]interface{} "Invalid question ID"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "Question not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /questions/{id}/status/history [get]
+func (h *QuestionHandler) GetQuestionStatusHistory(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Get question status history handler")
+
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		log.Errorf("Error parsing id: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid id"})
+	}
+
+	result, err := h.useCase.QuestionReviews(ctx.Context(), uint(id))
+	if err != nil {
+		log.Error(err)
+		return ctx.Status(questionErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	log.Info("Question status history found")
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"data": result})
+}
+
+// @Summary Get question by ID for authors
+// @Description Retrieve a question including its correct answer
+// @Tags Authoring
+// @Security BearerAuth
+// @Param id path uint true "Question ID"
+// @Produce json
+// @Success 200 {object} responses.AuthorQuestionResponse "Question details"
+// @Failure 400 {object} map[string]interface{} "Invalid question ID"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /author/questions/{id} [get]
+func (h *QuestionHandler) GetQuestionByIDForAuthor(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Get question for author handler")
+
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		log.Errorf("Error parsing id: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid id"})
+	}
+
+	result, err := h.useCase.FindByIDForAuthor(ctx.Context(), uint(id))
+	if err != nil {
+		log.Error(err)
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	log.Info("Question found")
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"data": result})
+}
+
+func parseQuestionQuery(ctx *fiber.Ctx) (requests.QuestionQuery, error) {
+	query := requests.QuestionQuery{
+		Tag:        ctx.Query("tag"),
+		Difficulty: ctx.Query("difficulty"),
+		Status:     ctx.Query("status"),
+	}
+
+	if category := ctx.Query("category"); category != "" {
+		id, err := strconv.ParseUint(category, 10, 64)
+		if err != nil {
+			return requests.QuestionQuery{}, errors.New("invalid category")
+		}
+		query.CategoryID = uint(id)
+	}
+
+	return query, nil
+}
+
+func questionErrorStatus(err error) int {
+	if errors.Is(err, questionsusecase.ErrInvalidQuestion) ||
+		errors.Is(err, questionsusecase.ErrInvalidDifficulty) ||
+		errors.Is(err, questionsusecase.ErrInvalidImport) {
+		return fiber.StatusBadRequest
+	}
+	if errors.Is(err, questionsusecase.ErrQuestionNotFound) {
+		return fiber.StatusNotFound
+	}
+	if errors.Is(err, questionsusecase.ErrQuestionPlayed) {
+		return fiber.StatusConflict
+	}
+	if status, ok := reviewErrorStatus(err); ok {
+		return status
+	}
+	return fiber.StatusInternalServerError
+}
+
+// reviewErrorStatus maps the editorial workflow errors shared by questions
+// and trivias.
+func reviewErrorStatus(err error) (int, bool) {
+	switch {
+	case errors.Is(err, reviewusecase.ErrInvalidStatus),
+		errors.Is(err, reviewusecase.ErrCommentRequired):
+		return fiber.StatusBadRequest, true
+	case errors.Is(err, reviewusecase.ErrReviewerRequired):
+		return fiber.StatusForbidden, true
+	case errors.Is(err, reviewusecase.ErrInvalidTransition),
+		errors.Is(err, reviewusecase.ErrNotEditable):
+		return fiber.StatusConflict, true
+	}
+	return 0, false
+}
+
+// canSeeDrafts reports whether the current user may see content that is
+// not published yet.
+func canSeeDrafts(ctx *fiber.Ctx) bool {
+	user := middleware.CurrentUser(ctx)
+	return user != nil && user.HasRole(models.RoleAuthor, models.RoleAdmin)
+}
+
+// parseStatusChange reads a status change made by the current user. Admins
+// are the reviewers.
+func parseStatusChange(ctx *fiber.Ctx) (requests.StatusChangeRequest, error) {
+	var req requests.StatusChangeRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return requests.StatusChangeRequest{}, err
+	}
+	user := middleware.CurrentUser(ctx)
+	req.UserID = user.ID
+	req.Reviewer = user.HasRole(models.RoleAdmin)
+	return req, nil
+}
+
+// sendBundle sends bundle as an attachment named after name in the format
+// of the format query parameter.
+func sendBundle(ctx *fiber.Ctx, bundle responses.ContentBundle, name string) error {
+	switch format := ctx.Query("format", "json"); format {
+	case "json":
+		ctx.Attachment(name + ".json")
+		return ctx.Status(fiber.StatusOK).JSON(bundle)
+	case "csv":
+		ctx.Attachment(name + ".csv")
+		ctx.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+		return questionsusecase.WriteBundleCSV(ctx, bundle.Questions)
+	default:
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("unknown format %q, expected json or csv", format)})
+	}
+}
+
+func readFormFile(header *multipart.FileHeader) ([]byte, error) {
+	file, err := header.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"context"
+	"sync"
+	roomusecase "talana_prueba_tecnica/src/app/usecases/room_usecase"
+	"talana_prueba_tecnica/src/entity/models"
+	"talana_prueba_tecnica/src/entity/requests"
+	"talana_prueba_tecnica/src/entity/responses"
+	"talana_prueba_tecnica/src/infraestructure/middleware"
+	"talana_prueba_tecnica/src/shared"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type RoomHandler struct {
+	useCase roomusecase.RoomUseCaseInterface
+}
+
+func NewRoomHandler(useCase roomusecase.RoomUseCaseInterface) *RoomHandler {
+	return &RoomHandler{
+		useCase: useCase,
+	}
+}
+
+// @Summary Create a live trivia room
+// @Description Create a room where the host runs a trivia live for all assigned users
+// @Tags Rooms
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param room body requests.CreateRoomRequest true "Room details"
+// @Success 201 {object} responses.RoomResponse "Room created"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "Trivia not published"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /rooms [post]
+func (h *RoomHandler) CreateRoom(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Create room handler")
+
+	var req requests.CreateRoomRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		log.Errorf("Error parsing request: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+	req.HostID = middleware.CurrentUser(ctx).ID
+
+	response, err := h.useCase.CreateRoom(ctx.Context(), &req)
+	if err != nil {
+		log.Errorf("Error creating room: %v", err)
+		return ctx.Status(gameErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	log.Info("Room created")
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{"data": response})
+}
+
+// @Summary Get a live trivia room
+// @Description Retrieve the state of a live trivia room
+// @Tags Rooms
+// @Security BearerAuth
+// @Param code path string true "Room code"
+// @Produce json
+// @Success 200 {object} responses.RoomResponse "Room state"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 404 {object} map[string]interface{} "Room not found"
+// @Router /rooms/{code} [get]
+func (h *RoomHandler) GetRoom(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Get room handler")
+
+	response, err := h.useCase.GetRoom(ctx.Context(), ctx.Params("code"))
+	if err != nil {
+		log.Errorf("Error getting room: %v", err)
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	log.Info("Room found")
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"data": response})
+}
+
+// Upgrade only lets WebSocket upgrade requests reach the room connection.
+func (h *RoomHandler) Upgrade(ctx *fiber.Ctx) error {
+	if websocket.IsWebSocketUpgrade(ctx) {
+		return ctx.Next()
+	}
+	return fiber.ErrUpgradeRequired
+}
+
+// @Summary Connect to a live trivia room
+// @Description Open the WebSocket of a room. The host sends {"type":"start"}, players send {"type":"answer","question_id":1,"selected_option":2} and every client receives room events (question, answer_result, leaderboard, finished)
+// @Tags Rooms
+// @Param code path string true "Room code"
+// @Param access_token query string true "Access token"
+// @Success 101 {object} responses.RoomEvent "Switching protocols"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 426 {object} map[string]interface{} "Upgrade required"
+// @Router /rooms/{code}/ws [get]
+func (h *RoomHandler) Connect(conn *websocket.Conn) {
+	// The request context ends with the upgrade, so each connection gets
+	// its own, cancelled once the player leaves.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	log := logrus.WithContext(ctx)
+	log.Info("Room connection handler")
+
+	code := conn.Params("code")
+	player := &websocketPlayer{conn: conn}
+
+	user, ok := conn.Locals(shared.CurrentUserKey).(*models.UserModel)
+	if !ok {
+		log.Error("Room connection without authenticated user")
+		_ = player.Send(responses.RoomEvent{Type: roomusecase.EventError, Data: "Authentication required"})
+		return
+	}
+	userID := user.ID
+
+	if err := h.useCase.Join(ctx, code, userID, player); err != nil {
+		log.Errorf("Error joining room: %v", err)
+		_ = player.Send(responses.RoomEvent{Type: roomusecase.EventError, Data: err.Error()})
+		return
+	}
+	defer h.useCase.Leave(ctx, code, userID, player)
+
+	for {
+		var msg requests.RoomMessage
+		if err := conn.ReadJSON(&msg); err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				log.Errorf("Error reading room message: %v", err)
+			}
+			return
+		}
+
+		if err := h.useCase.HandleMessage(ctx, code, userID, &msg); err != nil {
+			log.Errorf("Error handling room message: %v", err)
+			_ = player.Send(responses.RoomEvent{Type: roomusecase.EventError, Data: err.Error()})
+		}
+	}
+}
+
+type websocketPlayer struct {
+	mu   sync.Mutex
+	conn *websocket.Conn
+}
+
+func (p *websocketPlayer) Send(event responses.RoomEvent) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.conn.WriteJSON(event)
+}
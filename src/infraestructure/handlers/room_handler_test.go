@@ -0,0 +1,268 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	gameusecase "talana_prueba_tecnica/src/app/usecases/game_usecase"
+	roomusecase "talana_prueba_tecnica/src/app/usecases/room_usecase"
+	"talana_prueba_tecnica/src/entity/models"
+	"talana_prueba_tecnica/src/entity/requests"
+	"talana_prueba_tecnica/src/entity/responses"
+	triviarepository "talana_prueba_tecnica/src/infraestructure/repository/trivia_repository"
+	"talana_prueba_tecnica/src/shared"
+	"testing"
+	"time"
+
+	fasthttpws "github.com/fasthttp/websocket"
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	roomHostID     = 1
+	roomPlayerOne  = 2
+	roomPlayerTwo  = 3
+	roomTriviaID   = 7
+	roomQuestionID = 11
+	roomCorrect    = 1
+)
+
+// The fakes embed the interfaces they stand for so calls the test does not
+// expect panic.
+
+type fakeRoomTriviaRepo struct {
+	triviarepository.TriviaRepositoryInterface
+}
+
+func (r *fakeRoomTriviaRepo) FindByID(_ context.Context, id uint) (models.Trivia, error) {
+	if id != roomTriviaID {
+		return models.Trivia{}, gorm.ErrRecordNotFound
+	}
+	return models.Trivia{
+		ID:               roomTriviaID,
+		Status:           models.StatusPublished,
+		QuestionPoolSize: 1,
+		Questions: []models.Question{
+			{ID: roomQuestionID, Status: models.StatusPublished},
+			{ID: roomQuestionID + 1, Status: models.StatusPublished},
+		},
+		Users: []models.UserModel{
+			{ID: roomPlayerOne, Name: "Ana"},
+			{ID: roomPlayerTwo, Name: "Luis"},
+		},
+	}, nil
+}
+
+// fakeGameUseCase serves a single question to every session.
+type fakeGameUseCase struct {
+	gameusecase.GameUseCaseInterface
+
+	mu       sync.Mutex
+	starts   [][]uint
+	served   map[uint]bool
+	answered map[uint]bool
+}
+
+func (u *fakeGameUseCase) StartSessions(_ context.Context, triviaID uint, userIDs []uint) ([]responses.GameSessionResponse, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.starts = append(u.starts, userIDs)
+	var sessions []responses.GameSessionResponse
+	for _, userID := range userIDs {
+		sessions = append(sessions, responses.GameSessionResponse{
+			ID:             userID * 100,
+			TriviaID:       triviaID,
+			UserID:         userID,
+			TotalQuestions: 1,
+		})
+	}
+	return sessions, nil
+}
+
+func (u *fakeGameUseCase) NextQuestion(_ context.Context, _ uint, sessionID uint) (responses.SessionQuestionResponse, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.answered[sessionID] {
+		return responses.SessionQuestionResponse{}, gameusecase.ErrSessionFinished
+	}
+	u.served[sessionID] = true
+	return responses.SessionQuestionResponse{
+		SessionID:      sessionID,
+		Position:       1,
+		TotalQuestions: 1,
+		Question:       responses.QuestionResponse{ID: roomQuestionID},
+	}, nil
+}
+
+func (u *fakeGameUseCase) AnswerQuestion(_ context.Context, _ uint, sessionID uint, req *requests.SessionAnswerRequest) (responses.SessionAnswerResponse, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if !u.served[sessionID] || u.answered[sessionID] {
+		return responses.SessionAnswerResponse{}, gameusecase.ErrQuestionNotServed
+	}
+	u.answered[sessionID] = true
+
+	result := responses.SessionAnswerResponse{
+		QuestionID: req.QuestionID,
+		IsCorrect:  req.SelectedOption == roomCorrect,
+		Finished:   true,
+		Session:    responses.GameSessionResponse{ID: sessionID, AnsweredQuestions: 1, TotalQuestions: 1},
+	}
+	if result.IsCorrect {
+		result.Points = 10
+		result.Session.Score = 10
+		result.Session.CorrectAnswers = 1
+	}
+	return result, nil
+}
+
+type roomClient struct {
+	t    *testing.T
+	conn *fasthttpws.Conn
+}
+
+type roomClientEvent struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+func dialRoom(t *testing.T, addr, code string, userID uint) *roomClient {
+	t.Helper()
+
+	url := fmt.Sprintf("ws://%s/rooms/%s/ws?user=%d", addr, code, userID)
+	conn, _, err := fasthttpws.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("dialing room as user ID %d: %v", userID, err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return &roomClient{t: t, conn: conn}
+}
+
+func (c *roomClient) send(msg requests.RoomMessage) {
+	c.t.Helper()
+	if err := c.conn.WriteJSON(msg); err != nil {
+		c.t.Fatalf("sending %s: %v", msg.Type, err)
+	}
+}
+
+// await reads events until one of eventType arrives and decodes its data into
+// data, failing on room errors.
+func (c *roomClient) await(eventType string, data interface{}) {
+	c.t.Helper()
+
+	_ = c.conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for {
+		var event roomClientEvent
+		if err := c.conn.ReadJSON(&event); err != nil {
+			c.t.Fatalf("waiting for %s: %v", eventType, err)
+		}
+		if event.Type == roomusecase.EventError {
+			c.t.Fatalf("waiting for %s: room error %s", eventType, event.Data)
+		}
+		if event.Type != eventType {
+			continue
+		}
+		if data != nil {
+			if err := json.Unmarshal(event.Data, data); err != nil {
+				c.t.Fatalf("decoding %s: %v", eventType, err)
+			}
+		}
+		return
+	}
+}
+
+func TestRoomPlaysARoundWithTwoPlayers(t *testing.T) {
+	game := &fakeGameUseCase{served: map[uint]bool{}, answered: map[uint]bool{}}
+	useCase := roomusecase.NewRoomUseCase(game, &fakeRoomTriviaRepo{})
+	handler := NewRoomHandler(useCase)
+
+	room, err := useCase.CreateRoom(context.Background(), &requests.CreateRoomRequest{
+		TriviaID:        roomTriviaID,
+		HostID:          roomHostID,
+		QuestionSeconds: 5,
+	})
+	if err != nil {
+		t.Fatalf("creating room: %v", err)
+	}
+	if room.TotalRounds != 1 {
+		t.Fatalf("expected the question pool to limit the room to 1 round, got %d", room.TotalRounds)
+	}
+
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	authenticate := func(ctx *fiber.Ctx) error {
+		userID, err := strconv.Atoi(ctx.Query("user"))
+		if err != nil {
+			return fiber.ErrUnauthorized
+		}
+		ctx.Locals(shared.CurrentUserKey, &models.UserModel{ID: uint(userID)})
+		return ctx.Next()
+	}
+	app.Get("/rooms/:code/ws", handler.Upgrade, authenticate, websocket.New(handler.Connect))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listening: %v", err)
+	}
+	go func() { _ = app.Listener(listener) }()
+	t.Cleanup(func() { _ = app.Shutdown() })
+	addr := listener.Addr().String()
+
+	host := dialRoom(t, addr, room.Code, roomHostID)
+	host.await(roomusecase.EventPlayerJoined, nil)
+	one := dialRoom(t, addr, room.Code, roomPlayerOne)
+	one.await(roomusecase.EventPlayerJoined, nil)
+	two := dialRoom(t, addr, room.Code, roomPlayerTwo)
+	two.await(roomusecase.EventPlayerJoined, nil)
+
+	host.send(requests.RoomMessage{Type: roomusecase.MessageStart})
+
+	for _, player := range []struct {
+		client *roomClient
+		option uint
+	}{{one, roomCorrect}, {two, roomCorrect + 1}} {
+		var question responses.RoomQuestionEvent
+		player.client.await(roomusecase.EventQuestion, &question)
+		if question.Round != 1 || question.TotalRounds != 1 {
+			t.Fatalf("expected round 1 of 1, got %d of %d", question.Round, question.TotalRounds)
+		}
+
+		player.client.send(requests.RoomMessage{
+			Type:           roomusecase.MessageAnswer,
+			QuestionID:     question.Question.Question.ID,
+			SelectedOption: player.option,
+		})
+		var result responses.SessionAnswerResponse
+		player.client.await(roomusecase.EventAnswerResult, &result)
+		if result.IsCorrect != (player.option == roomCorrect) {
+			t.Fatalf("expected option %d to be graded correct=%t", player.option, player.option == roomCorrect)
+		}
+	}
+
+	for _, client := range []*roomClient{host, one, two} {
+		var leaderboard []responses.LeaderboardEntryResponse
+		client.await(roomusecase.EventFinished, &leaderboard)
+		if len(leaderboard) != 2 {
+			t.Fatalf("expected 2 players in the results, got %d", len(leaderboard))
+		}
+		if leaderboard[0].UserID != roomPlayerOne || leaderboard[0].Rank != 1 || leaderboard[0].Score != 10 {
+			t.Fatalf("expected user ID %d to win with 10 points, got %+v", roomPlayerOne, leaderboard[0])
+		}
+		if leaderboard[1].UserID != roomPlayerTwo || leaderboard[1].Rank != 2 || leaderboard[1].Score != 0 {
+			t.Fatalf("expected user ID %d second with 0 points, got %+v", roomPlayerTwo, leaderboard[1])
+		}
+	}
+
+	game.mu.Lock()
+	defer game.mu.Unlock()
+	if len(game.starts) != 1 || len(game.starts[0]) != 2 {
+		t.Fatalf("expected both players to be started together once, got %v", game.starts)
+	}
+}
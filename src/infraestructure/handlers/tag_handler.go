@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+	tagusecase "talana_prueba_tecnica/src/app/usecases/tag_usecase"
+	"talana_prueba_tecnica/src/entity/requests"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+type TagHandler struct {
+	useCase tagusecase.TagUseCaseInterface
+}
+
+func NewTagHandler(useCase tagusecase.TagUseCaseInterface) *TagHandler {
+	return &TagHandler{
+		useCase: useCase,
+	}
+}
+
+// @Summary Get all tags
+// @Description Retrieve every question tag
+// @Tags Tags
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} []responses.TagResponse "List of tags"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /tags [get]
+func (h *TagHandler) GetAllTags(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Get all tags handler")
+
+	result, err := h.useCase.FindAll(ctx.Context())
+	if err != nil {
+		log.Errorf("Error getting all tags: %v", err)
+		return ctx.Status(tagErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	log.Info("Tags found")
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"data": result})
+}
+
+// @Summary Create a tag
+// @Description Create a question tag; names are stored lowercased
+// @Tags Tags
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param tag body requests.CreateTagRequest true "Tag details"
+// @Success 201 {object} responses.TagResponse "Tag created"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /tags [post]
+func (h *TagHandler) CreateTag(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Create tag handler")
+
+	var req requests.CreateTagRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		log.Errorf("Error parsing request: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+
+	result, err := h.useCase.CreateTag(ctx.Context(), &req)
+	if err != nil {
+		log.Errorf("Error creating tag: %v", err)
+		return ctx.Status(tagErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	log.Info("Tag created")
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{"data": result})
+}
+
+// @Summary Rename a tag
+// @Description Rename a question tag
+// @Tags Tags
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path uint true "Tag ID"
+// @Param tag body requests.CreateTagRequest true "Tag details"
+// @Success 200 {object} responses.TagResponse "Tag updated"
+// @Failure 400 {object} map[string]interface{} "Invalid request or ID"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "Tag not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /tags/{id} [put]
+func (h *TagHandler) UpdateTag(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Update tag handler")
+
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		log.Errorf("Invalid tag ID: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid tag ID"})
+	}
+
+	var req requests.CreateTagRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		log.Errorf("Error parsing request: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+
+	result, err := h.useCase.UpdateTag(ctx.Context(), uint(id), &req)
+	if err != nil {
+		log.Errorf("Error updating tag: %v", err)
+		return ctx.Status(tagErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	log.Info("Tag updated")
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"data": result})
+}
+
+// @Summary Delete a tag
+// @Description Delete a tag, removing it from its questions
+// @Tags Tags
+// @Security BearerAuth
+// @Param id path uint true "Tag ID"
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Tag deleted"
+// @Failure 400 {object} map[string]interface{} "Invalid tag ID"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "Tag not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /tags/{id} [delete]
+func (h *TagHandler) DeleteTag(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Delete tag handler")
+
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		log.Errorf("Invalid tag ID: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid tag ID"})
+	}
+
+	if err := h.useCase.DeleteTag(ctx.Context(), uint(id)); err != nil {
+		log.Errorf("Error deleting tag: %v", err)
+		return ctx.Status(tagErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	log.Info("Tag deleted")
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Tag deleted"})
+}
+
+func tagErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, tagusecase.ErrTagNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, tagusecase.ErrInvalidTag):
+		return fiber.StatusBadRequest
+	}
+	return fiber.StatusInternalServerError
+}
@@ -1,8 +1,13 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
+	questionsusecase "talana_prueba_tecnica/src/app/usecases/questions_usecase"
 	triviausecase "talana_prueba_tecnica/src/app/usecases/trivia_usecase"
 	"talana_prueba_tecnica/src/entity/requests"
+	"talana_prueba_tecnica/src/entity/responses"
+	"talana_prueba_tecnica/src/infraestructure/middleware"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/sirupsen/logrus"
@@ -19,17 +24,19 @@ func NewTriviaHandler(useCase triviausecase.TriviaUseCaseInterface) *TriviaHandl
 }
 
 // @Summary Get all trivias
-// @Description Retrieve a list of all available trivias
+// @Description Retrieve a list of all available trivias. Players only see published trivias and their published questions.
 // @Tags Trivias
+// @Security BearerAuth
 // @Produce json
 // @Success 200 {object} []responses.TriviaResponse "List of trivias"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /trivias [get]
 func (h *TriviaHandler) GetAllTrivias(ctx *fiber.Ctx) error {
 	log := logrus.WithContext(ctx.Context())
 	log.Info("Get all trivias handler")
 
-	result, err := h.useCase.FindAll(ctx.Context())
+	result, err := h.useCase.FindAll(ctx.Context(), !canSeeDrafts(ctx))
 	if err != nil {
 		log.Errorf("Error getting all trivias: %v", err)
 		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
@@ -40,12 +47,15 @@ func (h *TriviaHandler) GetAllTrivias(ctx *fiber.Ctx) error {
 }
 
 // @Summary Get trivia by ID
-// @Description Retrieve details of a specific trivia by its ID
+// @Description Retrieve details of a specific trivia by its ID. Players only see published trivias, without their questions.
 // @Tags Trivias
+// @Security BearerAuth
 // @Param id path uint true "Trivia ID"
 // @Produce json
 // @Success 200 {object} responses.TriviaResponse "Trivia details"
 // @Failure 400 {object} map[string]interface{} "Invalid trivia ID"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 404 {object} map[string]interface{} "Trivia not found"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /trivias/{id} [get]
 func (h *TriviaHandler) GetTriviaByID(ctx *fiber.Ctx) error {
@@ -58,10 +68,10 @@ func (h *TriviaHandler) GetTriviaByID(ctx *fiber.Ctx) error {
 		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid trivia ID"})
 	}
 
-	result, err := h.useCase.FindByID(ctx.Context(), uint(id))
+	result, err := h.useCase.FindByID(ctx.Context(), uint(id), !canSeeDrafts(ctx))
 	if err != nil {
 		log.Errorf("Error getting trivia by ID: %v", err)
-		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return ctx.Status(triviaErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
 	}
 
 	log.Info("Trivia found")
@@ -71,11 +81,17 @@ func (h *TriviaHandler) GetTriviaByID(ctx *fiber.Ctx) error {
 // @Summary Create a new trivia
 // @Description Add a new trivia to the system
 // @Tags Trivias
+// @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param trivia body requests.CreateTriviaRequest true "Trivia details"
+// @Param Idempotency-Key header string false "Key making retries return the original response"
 // @Success 201 {object} map[string]interface{} "Trivia created"
 // @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 409 {object} map[string]interface{} "Request with this Idempotency-Key still in progress"
+// @Failure 422 {object} map[string]interface{} "Idempotency-Key used for a different request"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /trivias [post]
 func (h *TriviaHandler) CreateTrivia(ctx *fiber.Ctx) error {
@@ -105,15 +121,130 @@ func (h *TriviaHandler) CreateTrivia(ctx *fiber.Ctx) error {
 	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{"message": "Trivia created successfully"})
 }
 
+// @Summary Generate a trivia
+// @Description Create a trivia whose questions are picked at random from the bank by difficulty mix and categories, optionally skipping questions the assigned users already answered
+// @Tags Trivias
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param trivia body requests.GenerateTriviaRequest true "Trivia details and generation rules"
+// @Param Idempotency-Key header string false "Key making retries return the original response"
+// @Success 201 {object} responses.AuthorTriviaResponse "Trivia generated"
+// @Failure 400 {object} map[string]interface{} "Invalid request or generation rules"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 409 {object} map[string]interface{} "Request with this Idempotency-Key still in progress"
+// @Failure 422 {object} map[string]interface{} "Not enough matching questions, or Idempotency-Key used for a different request"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /trivias/generate [post]
+func (h *TriviaHandler) GenerateTrivia(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Generate trivia handler")
+
+	var req requests.GenerateTriviaRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		log.Errorf("Error parsing request: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+
+	if len(req.UserIDs) == 0 {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "At least one user is required"})
+	}
+
+	result, err := h.useCase.GenerateTrivia(ctx.Context(), &req)
+	if err != nil {
+		log.Errorf("Error generating trivia: %v", err)
+		return ctx.Status(triviaErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	log.Info("Trivia generated")
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{"data": result})
+}
+
+// @Summary Export a trivia
+// @Description Download the trivia settings and its questions as a versioned JSON bundle, or only its questions as a CSV file in the format read by the question import. User assignments are not exported.
+// @Tags Trivias
+// @Security BearerAuth
+// @Produce json,text/csv
+// @Param id path uint true "Trivia ID"
+// @Param format query string false "File format" Enums(json, csv) default(json)
+// @Success 200 {object} responses.ContentBundle "Trivia bundle"
+// @Failure 400 {object} map[string]interface{} "Invalid trivia ID or format"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "Trivia not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /trivias/{id}/export [get]
+func (h *TriviaHandler) ExportTrivia(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Export trivia handler")
+
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		log.Errorf("Invalid trivia ID: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid trivia ID"})
+	}
+
+	result, err := h.useCase.ExportTrivia(ctx.Context(), uint(id))
+	if err != nil {
+		log.Errorf("Error exporting trivia: %v", err)
+		return ctx.Status(triviaErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	log.Info("Trivia exported")
+	return sendBundle(ctx, result, fmt.Sprintf("trivia-%d", id))
+}
+
+// @Summary Import a trivia
+// @Description Create a trivia and new copies of its questions from a bundle exported by GET /trivias/{id}/export, possibly on another deployment. Missing categories and tags are created; trivia.user_ids may list the users to assign. Nothing is saved if any part of the bundle is invalid.
+// @Tags Trivias
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param bundle body responses.ContentBundle true "Trivia bundle"
+// @Param Idempotency-Key header string false "Key making retries return the original response"
+// @Success 201 {object} responses.AuthorTriviaResponse "Trivia imported"
+// @Failure 400 {object} map[string]interface{} "Invalid bundle"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 409 {object} map[string]interface{} "Request with this Idempotency-Key still in progress"
+// @Failure 422 {object} map[string]interface{} "Idempotency-Key used for a different request"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /trivias/import [post]
+func (h *TriviaHandler) ImportTrivia(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Import trivia handler")
+
+	var bundle responses.ContentBundle
+	if err := ctx.BodyParser(&bundle); err != nil {
+		log.Errorf("Error parsing bundle: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid bundle"})
+	}
+
+	result, err := h.useCase.ImportTrivia(ctx.Context(), &bundle)
+	if err != nil {
+		log.Errorf("Error importing trivia: %v", err)
+		return ctx.Status(triviaErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	log.Info("Trivia imported")
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{"data": result})
+}
+
 // @Summary Update a trivia
-// @Description Update the details of an existing trivia
+// @Description Update the details of an existing trivia. Only drafts can be edited.
 // @Tags Trivias
+// @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param id path uint true "Trivia ID"
 // @Param trivia body requests.CreateTriviaRequest true "Updated trivia details"
 // @Success 200 {object} map[string]interface{} "Trivia updated"
 // @Failure 400 {object} map[string]interface{} "Invalid request or trivia ID"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "Trivia not found"
+// @Failure 409 {object} map[string]interface{} "Trivia is not a draft"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /trivias/{id} [put]
 func (h *TriviaHandler) UpdateTrivia(ctx *fiber.Ctx) error {
@@ -134,7 +265,7 @@ func (h *TriviaHandler) UpdateTrivia(ctx *fiber.Ctx) error {
 
 	if err := h.useCase.UpdateTrivia(ctx.Context(), &req, uint(id)); err != nil {
 		log.Errorf("Error updating trivia: %v", err)
-		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return ctx.Status(triviaErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
 	}
 
 	log.Info("Trivia updated")
@@ -142,12 +273,16 @@ func (h *TriviaHandler) UpdateTrivia(ctx *fiber.Ctx) error {
 }
 
 // @Summary Delete a trivia
-// @Description Remove a trivia from the system
+// @Description Remove a trivia from the system. It is kept with its participations until purged, so admins can restore it.
 // @Tags Trivias
+// @Security BearerAuth
 // @Param id path uint true "Trivia ID"
 // @Produce json
 // @Success 200 {object} map[string]interface{} "Trivia deleted"
 // @Failure 400 {object} map[string]interface{} "Invalid trivia ID"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "Trivia not found"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /trivias/{id} [delete]
 func (h *TriviaHandler) DeleteTrivia(ctx *fiber.Ctx) error {
@@ -162,9 +297,425 @@ func (h *TriviaHandler) DeleteTrivia(ctx *fiber.Ctx) error {
 
 	if err := h.useCase.DeleteTrivia(ctx.Context(), uint(id)); err != nil {
 		log.Errorf("Error deleting trivia: %v", err)
-		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return ctx.Status(triviaErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
 	}
 
 	log.Info("Trivia deleted")
 	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Trivia deleted successfully"})
 }
+
+// @Summary Restore a trivia
+// @Description Bring back a deleted trivia with its questions, users and participations
+// @Tags Trivias
+// @Security BearerAuth
+// @Param id path uint true "Trivia ID"
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Trivia restored"
+// @Failure 400 {object} map[string]interface{} "Invalid trivia ID"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "Deleted trivia not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /trivias/{id}/restore [post]
+func (h *TriviaHandler) RestoreTrivia(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Restore trivia handler")
+
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		log.Errorf("Invalid trivia ID: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid trivia ID"})
+	}
+
+	if err := h.useCase.RestoreTrivia(ctx.Context(), uint(id)); err != nil {
+		log.Errorf("Error restoring trivia: %v", err)
+		return ctx.Status(triviaErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	log.Info("Trivia restored")
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Trivia restored successfully"})
+}
+
+// @Summary Purge a trivia
+// @Description Permanently remove a deleted trivia with its participations, answers and game sessions. Its questions are kept.
+// @Tags Trivias
+// @Security BearerAuth
+// @Param id path uint true "Trivia ID"
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Trivia purged"
+// @Failure 400 {object} map[string]interface{} "Invalid trivia ID"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "Deleted trivia not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /trivias/{id}/purge [delete]
+func (h *TriviaHandler) PurgeTrivia(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Purge trivia handler")
+
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		log.Errorf("Invalid trivia ID: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid trivia ID"})
+	}
+
+	if err := h.useCase.PurgeTrivia(ctx.Context(), uint(id)); err != nil {
+		log.Errorf("Error purging trivia: %v", err)
+		return ctx.Status(triviaErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	log.Info("Trivia purged")
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Trivia purged successfully"})
+}
+
+// @Summary Get trivia ranking
+// @Description Retrieve the leaderboard of a trivia, ties share the same rank
+// @Tags Trivias
+// @Security BearerAuth
+// @Param id path uint true "Trivia ID"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(20)
+// @Produce json
+// @Success 200 {object} responses.RankingResponse "Trivia ranking"
+// @Failure 400 {object} map[string]interface{} "Invalid trivia ID"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 404 {object} map[string]interface{} "Trivia not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /trivias/{id}/ranking [get]
+func (h *TriviaHandler) GetTriviaRanking(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Get trivia ranking handler")
+
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		log.Errorf("Invalid trivia ID: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid trivia ID"})
+	}
+
+	result, err := h.useCase.GetRanking(ctx.Context(), uint(id), ctx.QueryInt("page", 1), ctx.QueryInt("page_size", 20))
+	if err != nil {
+		log.Errorf("Error getting trivia ranking: %v", err)
+		return ctx.Status(triviaErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	log.Info("Trivia ranking found")
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"data": result})
+}
+
+// @Summary Assign a user to a trivia
+// @Description Allow a user to play a trivia
+// @Tags Trivias
+// @Security BearerAuth
+// @Param id path uint true "Trivia ID"
+// @Param userId path uint true "User ID"
+// @Produce json
+// @Success 200 {object} map[string]interface{} "User assigned"
+// @Failure 400 {object} map[string]interface{} "Invalid ID"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "Trivia or user not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /trivias/{id}/users/{userId} [post]
+func (h *TriviaHandler) AssignUser(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Assign user to trivia handler")
+
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		log.Errorf("Invalid trivia ID: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid trivia ID"})
+	}
+
+	userID, err := ctx.ParamsInt("userId")
+	if err != nil {
+		log.Errorf("Invalid user ID: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+
+	if err := h.useCase.AssignUserToTrivia(ctx.Context(), uint(id), uint(userID)); err != nil {
+		log.Errorf("Error assigning user to trivia: %v", err)
+		return ctx.Status(triviaErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	log.Info("User assigned to trivia")
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"message": "User assigned to trivia successfully"})
+}
+
+// @Summary Unassign a user from a trivia
+// @Description Stop a user from playing a trivia
+// @Tags Trivias
+// @Security BearerAuth
+// @Param id path uint true "Trivia ID"
+// @Param userId path uint true "User ID"
+// @Produce json
+// @Success 200 {object} map[string]interface{} "User unassigned"
+// @Failure 400 {object} map[string]interface{} "Invalid ID"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "Trivia or user not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /trivias/{id}/users/{userId} [delete]
+func (h *TriviaHandler) UnassignUser(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Unassign user from trivia handler")
+
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		log.Errorf("Invalid trivia ID: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid trivia ID"})
+	}
+
+	userID, err := ctx.ParamsInt("userId")
+	if err != nil {
+		log.Errorf("Invalid user ID: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+
+	if err := h.useCase.UnassignUserFromTrivia(ctx.Context(), uint(id), uint(userID)); err != nil {
+		log.Errorf("Error unassigning user from trivia: %v", err)
+		return ctx.Status(triviaErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	log.Info("User unassigned from trivia")
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"message": "User unassigned from trivia successfully"})
+}
+
+// @Summary Get a user score in a trivia
+// @Description Retrieve the latest finished participation of a user in a trivia with a per-answer breakdown. Players only see the correct answers and explanations once they have no attempts left.
+// @Tags Trivias
+// @Security BearerAuth
+// @Param id path uint true "Trivia ID"
+// @Param userId path uint true "User ID"
+// @Produce json
+// @Success 200 {object} responses.UserScoreResponse "User score"
+// @Failure 400 {object} map[string]interface{} "Invalid ID"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "Trivia or participation not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /trivias/{id}/users/{userId}/score [get]
+func (h *TriviaHandler) GetUserScore(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Get user score handler")
+
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		log.Errorf("Invalid trivia ID: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid trivia ID"})
+	}
+
+	userID, err := ctx.ParamsInt("userId")
+	if err != nil {
+		log.Errorf("Invalid user ID: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+
+	result, err := h.useCase.GetUserScore(ctx.Context(), uint(id), uint(userID), canSeeDrafts(ctx))
+	if err != nil {
+		log.Errorf("Error getting user score: %v", err)
+		return ctx.Status(triviaErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	log.Info("User score found")
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"data": result})
+}
+
+// @Summary Get user participations
+// @Description Retrieve the finished participations of a user, newest first, with a per-answer breakdown. Players only see the correct answers and explanations of trivias they have no attempts left for.
+// @Tags Users
+// @Security BearerAuth
+// @Param id path uint true "User ID"
+// @Produce json
+// @Success 200 {object} []responses.UserScoreResponse "User participations"
+// @Failure 400 {object} map[string]interface{} "Invalid user ID"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "User not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /users/{id}/participations [get]
+func (h *TriviaHandler) GetUserParticipations(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Get user participations handler")
+
+	userID, err := ctx.ParamsInt("id")
+	if err != nil {
+		log.Errorf("Invalid user ID: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+
+	result, err := h.useCase.GetUserParticipations(ctx.Context(), uint(userID), canSeeDrafts(ctx))
+	if err != nil {
+		log.Errorf("Error getting user participations: %v", err)
+		return ctx.Status(triviaErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	log.Info("User participations found")
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"data": result})
+}
+
+// @Summary Get trivia by ID for authors
+// @Description Retrieve a trivia including the correct answer of every question
+// @Tags Authoring
+// @Security BearerAuth
+// @Param id path uint true "Trivia ID"
+// @Produce json
+// @Success 200 {object} responses.AuthorTriviaResponse "Trivia details"
+// @Failure 400 {object} map[string]interface{} "Invalid trivia ID"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "Trivia not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /author/trivias/{id} [get]
+func (h *TriviaHandler) GetTriviaByIDForAuthor(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Get trivia for author handler")
+
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		log.Errorf("Invalid trivia ID: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid trivia ID"})
+	}
+
+	result, err := h.useCase.FindByIDForAuthor(ctx.Context(), uint(id))
+	if err != nil {
+		log.Errorf("Error getting trivia: %v", err)
+		return ctx.Status(triviaErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	log.Info("Trivia found")
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"data": result})
+}
+
+// @Summary Change trivia status
+// @Description Move a trivia through the editorial workflow: draft → in_review → published → archived, and archived back to draft. A trivia can only be sent to review or published once all its questions are published. Only reviewers (admins) can publish a trivia in review or send it back to draft, which requires a comment.
+// @Tags Authoring
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path uint true "Trivia ID"
+// @Param status body requests.StatusChangeRequest true "New status and reviewer comment"
+// @Success 200 {object} responses.AuthorTriviaResponse "Trivia with its new status"
+// @Failure 400 {object} map[string]interface{} "Invalid request, status or missing comment"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions or not a reviewer"
+// @Failure 404 {object} map[string]interface{} "Trivia not found"
+// @Failure 409 {object} map[string]interface{} "Status change not allowed or questions not published"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /trivias/{id}/status [post]
+func (h *TriviaHandler) ChangeTriviaStatus(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Change trivia status handler")
+
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		log.Errorf("Invalid trivia ID: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid trivia ID"})
+	}
+
+	req, err := parseStatusChange(ctx)
+	if err != nil {
+		log.Errorf("Error parsing request: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request"})
+	}
+
+	result, err := h.useCase.ChangeTriviaStatus(ctx.Context(), uint(id), &req)
+	if err != nil {
+		log.Errorf("Error changing trivia status: %v", err)
+		return ctx.Status(triviaErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	log.Info("Trivia status changed")
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"data": result})
+}
+
+// @Summary Get trivia status history
+// @Description Retrieve the status changes of a trivia with the reviewer comments, oldest first
+// @Tags Authoring
+// @Security BearerAuth
+// @Param id path uint true "Trivia ID"
+// @Produce json
+// @Success 200 {object} []responses.ReviewEventResponse "Status changes"
+// @Failure 400 {object} map[string]interface{} "Invalid trivia ID"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "Trivia not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /trivias/{id}/status/history [get]
+func (h *TriviaHandler) GetTriviaStatusHistory(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Get trivia status history handler")
+
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		log.Errorf("Invalid trivia ID: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid trivia ID"})
+	}
+
+	result, err := h.useCase.TriviaReviews(ctx.Context(), uint(id))
+	if err != nil {
+		log.Errorf("Error getting trivia status history: %v", err)
+		return ctx.Status(triviaErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	log.Info("Trivia status history found")
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"data": result})
+}
+
+// @Summary Review a completed trivia
+// @Description Retrieve a trivia with its correct answers, only once the current user has completed it and has no attempts left. Trivias with unlimited attempts cannot be reviewed.
+// @Tags Trivias
+// @Security BearerAuth
+// @Param id path uint true "Trivia ID"
+// @Produce json
+// @Success 200 {object} responses.AuthorTriviaResponse "Trivia with answers"
+// @Failure 400 {object} map[string]interface{} "Invalid trivia ID"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Trivia not completed or attempts left"
+// @Failure 404 {object} map[string]interface{} "Trivia not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /trivias/{id}/review [get]
+func (h *TriviaHandler) GetTriviaReview(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Get trivia review handler")
+
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		log.Errorf("Invalid trivia ID: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid trivia ID"})
+	}
+
+	result, err := h.useCase.GetReview(ctx.Context(), uint(id), middleware.CurrentUser(ctx).ID)
+	if err != nil {
+		log.Errorf("Error getting trivia review: %v", err)
+		return ctx.Status(triviaErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	log.Info("Trivia review found")
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{"data": result})
+}
+
+func triviaErrorStatus(err error) int {
+	if errors.Is(err, triviausecase.ErrTriviaNotFound) ||
+		errors.Is(err, triviausecase.ErrUserNotFound) ||
+		errors.Is(err, triviausecase.ErrParticipationNotFound) {
+		return fiber.StatusNotFound
+	}
+	if errors.Is(err, triviausecase.ErrReviewNotAvailable) {
+		return fiber.StatusForbidden
+	}
+	if errors.Is(err, triviausecase.ErrInvalidGeneration) ||
+		errors.Is(err, triviausecase.ErrInvalidBundle) ||
+		errors.Is(err, questionsusecase.ErrInvalidQuestion) {
+		return fiber.StatusBadRequest
+	}
+	if errors.Is(err, triviausecase.ErrNotEnoughQuestions) {
+		return fiber.StatusUnprocessableEntity
+	}
+	if errors.Is(err, triviausecase.ErrUnpublishedQuestions) {
+		return fiber.StatusConflict
+	}
+	if status, ok := reviewErrorStatus(err); ok {
+		return status
+	}
+	return fiber.StatusInternalServerError
+}
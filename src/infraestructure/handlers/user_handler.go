@@ -1,9 +1,11 @@
 package handlers
 
 import (
+	"errors"
 	"strconv"
 	usecases "talana_prueba_tecnica/src/app/usecases/user_usecase"
 	"talana_prueba_tecnica/src/entity/requests"
+	"talana_prueba_tecnica/src/shared"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/sirupsen/logrus"
@@ -20,8 +22,11 @@ func NewUserHandler(usecase usecases.UserUseCaseInterface) UserHandler {
 // @Summary Get all users
 // @Description Retrieve a list of all registered users
 // @Tags Users
+// @Security BearerAuth
 // @Produce json
 // @Success 200 {object} []responses.UserResponse "List of users"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /users [get]
 func (h *UserHandler) GetAllUsers(ctx *fiber.Ctx) error {
@@ -41,10 +46,13 @@ func (h *UserHandler) GetAllUsers(ctx *fiber.Ctx) error {
 // @Summary Get user by ID
 // @Description Retrieve a specific user by their ID
 // @Tags Users
+// @Security BearerAuth
 // @Param id path uint true "User ID"
 // @Produce json
 // @Success 200 {object} responses.UserResponse "User details"
 // @Failure 400 {object} map[string]interface{} "Invalid user ID"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /users/{id} [get]
 func (h *UserHandler) GetUserByID(ctx *fiber.Ctx) error {
@@ -75,11 +83,17 @@ func (h *UserHandler) GetUserByID(ctx *fiber.Ctx) error {
 // @Summary Create a new user
 // @Description Register a new user in the system
 // @Tags Users
+// @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param user body requests.RegisterUserRequest true "User details"
+// @Param Idempotency-Key header string false "Key making retries return the original response"
 // @Success 201 {object} map[string]interface{} "User created"
 // @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 409 {object} map[string]interface{} "Request with this Idempotency-Key still in progress"
+// @Failure 422 {object} map[string]interface{} "Idempotency-Key used for a different request"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /users [post]
 func (h *UserHandler) CreateUser(ctx *fiber.Ctx) error {
@@ -93,6 +107,10 @@ func (h *UserHandler) CreateUser(ctx *fiber.Ctx) error {
 	}
 
 	err := h.usecase.CreateUser(ctx.Context(), userRequest)
+	if errors.Is(err, shared.ErrPasswordTooShort) {
+		log.Error(err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
 	if err != nil {
 		log.Error(err)
 		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
@@ -107,12 +125,15 @@ func (h *UserHandler) CreateUser(ctx *fiber.Ctx) error {
 // @Summary Update a user
 // @Description Update the details of an existing user
 // @Tags Users
+// @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param id path uint true "User ID"
 // @Param user body requests.UpdateUserRequest true "Updated user details"
 // @Success 200 {object} map[string]interface{} "User updated"
 // @Failure 400 {object} map[string]interface{} "Invalid request or ID"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /users/{id} [put]
 func (h *UserHandler) UpdateUser(ctx *fiber.Ctx) error {
@@ -149,12 +170,15 @@ func (h *UserHandler) UpdateUser(ctx *fiber.Ctx) error {
 }
 
 // @Summary Delete a user
-// @Description Remove a user from the system
+// @Description Remove a user from the system. The user can no longer sign in but is kept with their participations until purged, so admins can restore them.
 // @Tags Users
+// @Security BearerAuth
 // @Param id path uint true "User ID"
 // @Produce json
 // @Success 200 {object} map[string]interface{} "User deleted"
 // @Failure 400 {object} map[string]interface{} "Invalid user ID"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /users/{id} [delete]
 func (h *UserHandler) DeleteUser(ctx *fiber.Ctx) error {
@@ -182,3 +206,155 @@ func (h *UserHandler) DeleteUser(ctx *fiber.Ctx) error {
 		"message": "User deleted",
 	})
 }
+
+// @Summary Restore a user
+// @Description Bring back a deleted user with their roles and participations
+// @Tags Users
+// @Security BearerAuth
+// @Param id path uint true "User ID"
+// @Produce json
+// @Success 200 {object} map[string]interface{} "User restored"
+// @Failure 400 {object} map[string]interface{} "Invalid user ID"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "Deleted user not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /users/{id}/restore [post]
+func (h *UserHandler) RestoreUser(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Restore user handler")
+
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		log.Errorf("Error parsing id: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid id"})
+	}
+
+	err = h.usecase.RestoreUser(ctx.Context(), uint(id))
+	if err != nil {
+		log.Error(err)
+		return ctx.Status(userErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	log.Info("User restored")
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "User restored",
+	})
+}
+
+// @Summary Purge a user
+// @Description Permanently remove a deleted user with their roles, trivia assignments and participations
+// @Tags Users
+// @Security BearerAuth
+// @Param id path uint true "User ID"
+// @Produce json
+// @Success 200 {object} map[string]interface{} "User purged"
+// @Failure 400 {object} map[string]interface{} "Invalid user ID"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "Deleted user not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /users/{id}/purge [delete]
+func (h *UserHandler) PurgeUser(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Purge user handler")
+
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		log.Errorf("Error parsing id: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid id"})
+	}
+
+	err = h.usecase.PurgeUser(ctx.Context(), uint(id))
+	if err != nil {
+		log.Error(err)
+		return ctx.Status(userErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	log.Info("User purged")
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "User purged",
+	})
+}
+
+// @Summary Grant a role
+// @Description Grant a role (admin, author or player) to a user
+// @Tags Users
+// @Security BearerAuth
+// @Param id path uint true "User ID"
+// @Param role path string true "Role" Enums(admin, author, player)
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Role granted"
+// @Failure 400 {object} map[string]interface{} "Invalid user ID or role"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "User not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /users/{id}/roles/{role} [post]
+func (h *UserHandler) GrantRole(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Grant role handler")
+
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		log.Errorf("Error parsing id: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid id"})
+	}
+
+	err = h.usecase.GrantRole(ctx.Context(), uint(id), ctx.Params("role"))
+	if err != nil {
+		log.Error(err)
+		return ctx.Status(userErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	log.Info("Role granted")
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Role granted",
+	})
+}
+
+// @Summary Revoke a role
+// @Description Revoke a role (admin, author or player) from a user
+// @Tags Users
+// @Security BearerAuth
+// @Param id path uint true "User ID"
+// @Param role path string true "Role" Enums(admin, author, player)
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Role revoked"
+// @Failure 400 {object} map[string]interface{} "Invalid user ID or role"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Insufficient permissions"
+// @Failure 404 {object} map[string]interface{} "User not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /users/{id}/roles/{role} [delete]
+func (h *UserHandler) RevokeRole(ctx *fiber.Ctx) error {
+	log := logrus.WithContext(ctx.Context())
+	log.Info("Revoke role handler")
+
+	id, err := strconv.ParseUint(ctx.Params("id"), 10, 64)
+	if err != nil {
+		log.Errorf("Error parsing id: %v", err)
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid id"})
+	}
+
+	err = h.usecase.RevokeRole(ctx.Context(), uint(id), ctx.Params("role"))
+	if err != nil {
+		log.Error(err)
+		return ctx.Status(userErrorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	log.Info("Role revoked")
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Role revoked",
+	})
+}
+
+func userErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, usecases.ErrUserNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, usecases.ErrInvalidRole):
+		return fiber.StatusBadRequest
+	}
+	return fiber.StatusInternalServerError
+}
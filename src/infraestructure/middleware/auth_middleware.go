@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"strings"
+	"talana_prueba_tecnica/src/entity/models"
+	repository "talana_prueba_tecnica/src/infraestructure/repository/user_repository"
+	"talana_prueba_tecnica/src/shared"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// Authenticate resolves the user of the bearer access token into the request
+// context. Browsers cannot set headers on WebSocket upgrades, so the token is
+// also accepted in the access_token query parameter.
+func Authenticate(userRepository repository.UserRepositoryInterface) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		log := logrus.WithContext(ctx.Context())
+
+		token := strings.TrimPrefix(ctx.Get(fiber.HeaderAuthorization), "Bearer ")
+		if token == "" {
+			token = ctx.Query("access_token")
+		}
+		if token == "" {
+			log.Error("Missing access token")
+			return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Authentication required"})
+		}
+
+		userID, err := shared.ParseToken(token, shared.AccessTokenType)
+		if err != nil {
+			log.WithError(err).Error("Invalid access token")
+			return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		user, err := userRepository.FindByID(ctx.Context(), userID)
+		if err != nil {
+			log.WithError(err).Errorf("User ID %d of the token not found", userID)
+			return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": shared.ErrInvalidToken.Error()})
+		}
+
+		ctx.Locals(shared.CurrentUserKey, user)
+		return ctx.Next()
+	}
+}
+
+// CurrentUser returns the user resolved by Authenticate.
+func CurrentUser(ctx *fiber.Ctx) *models.UserModel {
+	user, _ := ctx.Locals(shared.CurrentUserKey).(*models.UserModel)
+	return user
+}
@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"talana_prueba_tecnica/src/entity/models"
+	idempotencyrepository "talana_prueba_tecnica/src/infraestructure/repository/idempotency_repository"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	IdempotencyKeyHeader = "Idempotency-Key"
+	maxIdempotencyKeyLen = 255
+
+	// idempotencyReservationTTL is how long a request may hold its key
+	// before a retry takes the reservation over, so a request that died
+	// without releasing it does not block the key forever.
+	idempotencyReservationTTL = time.Minute
+)
+
+// Idempotency makes create endpoints safe to retry. The first successful
+// response of a request sent with an Idempotency-Key is stored and replayed
+// for every retry of the same user, method, path and body. A request that
+// fails, panics or holds its key longer than idempotencyReservationTTL lets
+// the next retry run again. It must run after Authenticate.
+func Idempotency(repo idempotencyrepository.IdempotencyRepositoryInterface) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		log := logrus.WithContext(ctx.Context())
+
+		key := ctx.Get(IdempotencyKeyHeader)
+		if key == "" {
+			return ctx.Next()
+		}
+		if len(key) > maxIdempotencyKeyLen {
+			log.Error("Idempotency key too long")
+			return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Idempotency-Key must be at most 255 characters"})
+		}
+
+		var userID uint
+		if user := CurrentUser(ctx); user != nil {
+			userID = user.ID
+		}
+
+		record := &models.IdempotencyRecord{
+			UserID:      userID,
+			Key:         key,
+			RequestHash: requestHash(ctx),
+		}
+		reserved, err := repo.Reserve(ctx.Context(), record)
+		if err == nil && !reserved {
+			reserved, err = repo.TakeOver(ctx.Context(), record, time.Now().Add(-idempotencyReservationTTL))
+		}
+		if err != nil {
+			return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Could not process Idempotency-Key"})
+		}
+
+		if !reserved {
+			return replay(ctx, repo, record)
+		}
+
+		defer func() {
+			if p := recover(); p != nil {
+				_ = repo.Release(ctx.Context(), record.ID)
+				panic(p)
+			}
+		}()
+
+		if err := ctx.Next(); err != nil {
+			_ = repo.Release(ctx.Context(), record.ID)
+			return err
+		}
+
+		status := ctx.Response().StatusCode()
+		if status < fiber.StatusOK || status >= fiber.StatusMultipleChoices {
+			_ = repo.Release(ctx.Context(), record.ID)
+			return nil
+		}
+
+		record.StatusCode = status
+		record.ContentType = string(ctx.Response().Header.ContentType())
+		record.Body = append([]byte(nil), ctx.Response().Body()...)
+		if err := repo.Complete(ctx.Context(), record); err != nil {
+			log.WithError(err).Error("Error storing idempotent response")
+		}
+		return nil
+	}
+}
+
+func replay(ctx *fiber.Ctx, repo idempotencyrepository.IdempotencyRepositoryInterface, record *models.IdempotencyRecord) error {
+	log := logrus.WithContext(ctx.Context())
+
+	stored, err := repo.Find(ctx.Context(), record.UserID, record.Key)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Error("Idempotency key released while replaying")
+		return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Request with this Idempotency-Key is still being processed"})
+	}
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Could not process Idempotency-Key"})
+	}
+
+	if stored.RequestHash != record.RequestHash {
+		log.Error("Idempotency key reused with a different request")
+		return ctx.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{"error": "Idempotency-Key was already used for a different request"})
+	}
+
+	if stored.StatusCode == 0 {
+		log.Error("Idempotent request still in progress")
+		return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Request with this Idempotency-Key is still being processed"})
+	}
+
+	log.Infof("Replaying response for idempotency record ID %d", stored.ID)
+	ctx.Set("Idempotent-Replayed", "true")
+	ctx.Set(fiber.HeaderContentType, stored.ContentType)
+	return ctx.Status(stored.StatusCode).Send(stored.Body)
+}
+
+func requestHash(ctx *fiber.Ctx) string {
+	hash := sha256.New()
+	hash.Write([]byte(ctx.Method()))
+	hash.Write([]byte{0})
+	hash.Write([]byte(ctx.Path()))
+	hash.Write([]byte{0})
+	hash.Write(ctx.Body())
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,181 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"talana_prueba_tecnica/src/entity/models"
+	idempotencyrepository "talana_prueba_tecnica/src/infraestructure/repository/idempotency_repository"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/recover"
+	"gorm.io/gorm"
+)
+
+// fakeIdempotencyRepo keeps the records of a single user in memory.
+type fakeIdempotencyRepo struct {
+	idempotencyrepository.IdempotencyRepositoryInterface
+	records map[string]*models.IdempotencyRecord
+	nextID  uint
+}
+
+func (r *fakeIdempotencyRepo) Reserve(_ context.Context, record *models.IdempotencyRecord) (bool, error) {
+	if _, ok := r.records[record.Key]; ok {
+		return false, nil
+	}
+	r.nextID++
+	record.ID = r.nextID
+	record.CreatedAt = time.Now()
+	stored := *record
+	r.records[record.Key] = &stored
+	return true, nil
+}
+
+func (r *fakeIdempotencyRepo) TakeOver(_ context.Context, record *models.IdempotencyRecord, staleBefore time.Time) (bool, error) {
+	stored, ok := r.records[record.Key]
+	if !ok || stored.StatusCode != 0 || !stored.CreatedAt.Before(staleBefore) {
+		return false, nil
+	}
+	stored.RequestHash = record.RequestHash
+	stored.CreatedAt = time.Now()
+	record.ID = stored.ID
+	return true, nil
+}
+
+func (r *fakeIdempotencyRepo) Find(_ context.Context, _ uint, key string) (*models.IdempotencyRecord, error) {
+	stored, ok := r.records[key]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	found := *stored
+	return &found, nil
+}
+
+func (r *fakeIdempotencyRepo) Complete(_ context.Context, record *models.IdempotencyRecord) error {
+	stored := *record
+	r.records[record.Key] = &stored
+	return nil
+}
+
+func (r *fakeIdempotencyRepo) Release(_ context.Context, id uint) error {
+	for key, stored := range r.records {
+		if stored.ID == id {
+			delete(r.records, key)
+		}
+	}
+	return nil
+}
+
+func TestIdempotency(t *testing.T) {
+	const key = "create-trivia-1"
+	body := `{"name":"Geografía"}`
+
+	tests := []struct {
+		name string
+		// prime sends body once with the key before the request checked,
+		// and prepare then adjusts the stored record.
+		prime        bool
+		prepare      func(record *models.IdempotencyRecord)
+		key          string
+		body         string
+		handler      int
+		want         int
+		wantCalls    int
+		wantReplayed bool
+		wantStored   int
+	}{
+		{name: "no key", body: body, handler: fiber.StatusCreated, want: fiber.StatusCreated, wantCalls: 1, wantStored: -1},
+		{name: "key too long", key: strings.Repeat("k", 256), body: body, handler: fiber.StatusCreated, want: fiber.StatusBadRequest, wantStored: -1},
+		{name: "first request", key: key, body: body, handler: fiber.StatusCreated, want: fiber.StatusCreated, wantCalls: 1, wantStored: fiber.StatusCreated},
+		{name: "retry", prime: true, key: key, body: body, handler: fiber.StatusCreated, want: fiber.StatusCreated, wantReplayed: true, wantStored: fiber.StatusCreated},
+		{name: "key reused for another body", prime: true, key: key, body: `{"name":"Historia"}`, handler: fiber.StatusCreated, want: fiber.StatusUnprocessableEntity, wantStored: fiber.StatusCreated},
+		{
+			name:       "retry while the request runs",
+			prime:      true,
+			prepare:    func(record *models.IdempotencyRecord) { record.StatusCode = 0 },
+			key:        key,
+			body:       body,
+			handler:    fiber.StatusCreated,
+			want:       fiber.StatusConflict,
+			wantStored: 0,
+		},
+		{
+			name:  "retry after the request stalled",
+			prime: true,
+			prepare: func(record *models.IdempotencyRecord) {
+				record.StatusCode = 0
+				record.CreatedAt = time.Now().Add(-2 * idempotencyReservationTTL)
+			},
+			key:        key,
+			body:       body,
+			handler:    fiber.StatusCreated,
+			want:       fiber.StatusCreated,
+			wantCalls:  1,
+			wantStored: fiber.StatusCreated,
+		},
+		{name: "failed request", key: key, body: body, handler: fiber.StatusBadRequest, want: fiber.StatusBadRequest, wantCalls: 1, wantStored: -1},
+		{name: "panicking request", key: key, body: body, want: fiber.StatusInternalServerError, wantCalls: 1, wantStored: -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeIdempotencyRepo{records: map[string]*models.IdempotencyRecord{}}
+			calls := 0
+			status := fiber.StatusCreated
+
+			app := fiber.New()
+			app.Use(recover.New())
+			app.Post("/trivias", withUser(userWithRoles(1, models.RoleAuthor)), Idempotency(repo), func(ctx *fiber.Ctx) error {
+				calls++
+				if status == 0 {
+					panic("handler failed")
+				}
+				return ctx.Status(status).JSON(fiber.Map{"call": calls})
+			})
+			send := func(body string) *http.Response {
+				req := httptest.NewRequest(fiber.MethodPost, "/trivias", strings.NewReader(body))
+				req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+				if tt.key != "" {
+					req.Header.Set(IdempotencyKeyHeader, tt.key)
+				}
+				resp, err := app.Test(req)
+				if err != nil {
+					t.Fatalf("request: %v", err)
+				}
+				return resp
+			}
+
+			if tt.prime {
+				send(body)
+				if tt.prepare != nil {
+					tt.prepare(repo.records[tt.key])
+				}
+				calls = 0
+			}
+
+			status = tt.handler
+			resp := send(tt.body)
+			if resp.StatusCode != tt.want {
+				t.Errorf("status %d, want %d", resp.StatusCode, tt.want)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("handler ran %d times, want %d", calls, tt.wantCalls)
+			}
+			if replayed := resp.Header.Get("Idempotent-Replayed") == "true"; replayed != tt.wantReplayed {
+				t.Errorf("replayed %v, want %v", replayed, tt.wantReplayed)
+			}
+
+			stored, ok := repo.records[tt.key]
+			switch {
+			case tt.wantStored < 0 && ok:
+				t.Errorf("stored a record with status %d, want none", stored.StatusCode)
+			case tt.wantStored >= 0 && !ok:
+				t.Errorf("stored no record, want one with status %d", tt.wantStored)
+			case ok && stored.StatusCode != tt.wantStored:
+				t.Errorf("stored status %d, want %d", stored.StatusCode, tt.wantStored)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// RequireRoles only lets through users holding any of roles. It must run
+// after Authenticate.
+func RequireRoles(roles ...string) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		log := logrus.WithContext(ctx.Context())
+
+		user := CurrentUser(ctx)
+		if user == nil {
+			log.Error("Role check without authenticated user")
+			return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Authentication required"})
+		}
+
+		if !user.HasRole(roles...) {
+			log.Errorf("User ID %d lacks any of the roles %v", user.ID, roles)
+			return ctx.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Insufficient permissions"})
+		}
+
+		return ctx.Next()
+	}
+}
+
+// RequireSelfOrRoles lets users through when the path parameter param is their
+// own ID, and otherwise only if they hold any of roles. It must run after
+// Authenticate.
+func RequireSelfOrRoles(param string, roles ...string) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		user := CurrentUser(ctx)
+		if user != nil && ctx.Params(param) == strconv.FormatUint(uint64(user.ID), 10) {
+			return ctx.Next()
+		}
+		return RequireRoles(roles...)(ctx)
+	}
+}
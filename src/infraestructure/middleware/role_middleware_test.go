@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"talana_prueba_tecnica/src/entity/models"
+	"talana_prueba_tecnica/src/shared"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// withUser stands in for Authenticate, setting user as the current user.
+func withUser(user *models.UserModel) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		if user != nil {
+			ctx.Locals(shared.CurrentUserKey, user)
+		}
+		return ctx.Next()
+	}
+}
+
+func userWithRoles(id uint, roles ...string) *models.UserModel {
+	user := &models.UserModel{ID: id}
+	for _, role := range roles {
+		user.Roles = append(user.Roles, models.UserRole{UserID: id, Role: role})
+	}
+	return user
+}
+
+func TestRequireRoles(t *testing.T) {
+	tests := []struct {
+		name string
+		user *models.UserModel
+		want int
+	}{
+		{"anonymous", nil, fiber.StatusUnauthorized},
+		{"without roles", userWithRoles(1), fiber.StatusForbidden},
+		{"other role", userWithRoles(1, models.RolePlayer), fiber.StatusForbidden},
+		{"first role", userWithRoles(1, models.RoleAuthor), fiber.StatusOK},
+		{"second role", userWithRoles(1, models.RolePlayer, models.RoleAdmin), fiber.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Get("/", withUser(tt.user), RequireRoles(models.RoleAuthor, models.RoleAdmin), func(ctx *fiber.Ctx) error {
+				return ctx.SendStatus(fiber.StatusOK)
+			})
+
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil))
+			if err != nil {
+				t.Fatalf("request: %v", err)
+			}
+			if resp.StatusCode != tt.want {
+				t.Errorf("status %d, want %d", resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequireSelfOrRoles(t *testing.T) {
+	tests := []struct {
+		name string
+		user *models.UserModel
+		path string
+		want int
+	}{
+		{"anonymous", nil, "/users/7", fiber.StatusUnauthorized},
+		{"own ID", userWithRoles(7, models.RolePlayer), "/users/7", fiber.StatusOK},
+		{"own ID without roles", userWithRoles(7), "/users/7", fiber.StatusOK},
+		{"other ID", userWithRoles(7, models.RolePlayer), "/users/8", fiber.StatusForbidden},
+		{"other ID as admin", userWithRoles(7, models.RoleAdmin), "/users/8", fiber.StatusOK},
+		{"own ID with a leading zero", userWithRoles(7, models.RolePlayer), "/users/07", fiber.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Get("/users/:id", withUser(tt.user), RequireSelfOrRoles("id", models.RoleAdmin), func(ctx *fiber.Ctx) error {
+				return ctx.SendStatus(fiber.StatusOK)
+			})
+
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, tt.path, nil))
+			if err != nil {
+				t.Fatalf("request: %v", err)
+			}
+			if resp.StatusCode != tt.want {
+				t.Errorf("status %d, want %d", resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
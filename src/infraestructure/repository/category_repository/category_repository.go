@@ -0,0 +1,125 @@
+package categoryrepository
+
+import (
+	"context"
+	"talana_prueba_tecnica/src/entity/models"
+	"talana_prueba_tecnica/src/shared"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type CategoryRepository struct {
+	db *gorm.DB
+}
+
+func NewCategoryRepository(db *gorm.DB) *CategoryRepository {
+	return &CategoryRepository{db: db}
+}
+
+func (r *CategoryRepository) CreateCategory(ctx context.Context, category *models.Category) error {
+	log := logrus.WithContext(ctx)
+	log.Info("Creating category")
+
+	if err := shared.Conn(ctx, r.db).Create(category).Error; err != nil {
+		log.WithError(err).Error("Error creating category")
+		return err
+	}
+
+	log.Info("Category created")
+	return nil
+}
+
+func (r *CategoryRepository) FindAll(ctx context.Context) ([]models.Category, error) {
+	log := logrus.WithContext(ctx)
+	log.Info("Finding all categories")
+
+	var categories []models.Category
+	if err := shared.Conn(ctx, r.db).Order("name").Find(&categories).Error; err != nil {
+		log.WithError(err).Error("Error finding all categories")
+		return nil, err
+	}
+
+	log.Info("Categories found")
+	return categories, nil
+}
+
+func (r *CategoryRepository) FindByID(ctx context.Context, id uint) (*models.Category, error) {
+	log := logrus.WithContext(ctx)
+	log.Infof("Finding category by ID: %d", id)
+
+	var category models.Category
+	err := shared.Conn(ctx, r.db).
+		Preload("Children", func(db *gorm.DB) *gorm.DB {
+			return db.Order("name")
+		}).
+		First(&category, id).Error
+	if err != nil {
+		log.WithError(err).Error("Error finding category by ID")
+		return nil, err
+	}
+
+	log.Info("Category found")
+	return &category, nil
+}
+
+func (r *CategoryRepository) FindByIDs(ctx context.Context, ids []uint) ([]models.Category, error) {
+	log := logrus.WithContext(ctx)
+	log.Infof("Finding categories by IDs: %v", ids)
+
+	var categories []models.Category
+	if err := shared.Conn(ctx, r.db).Where("id IN ?", ids).Find(&categories).Error; err != nil {
+		log.WithError(err).Error("Error finding categories by IDs")
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+func (r *CategoryRepository) UpdateCategory(ctx context.Context, category *models.Category) error {
+	log := logrus.WithContext(ctx)
+	log.Infof("Updating category with ID: %d", category.ID)
+
+	err := shared.Conn(ctx, r.db).Model(category).Select("name", "parent_id").Updates(category).Error
+	if err != nil {
+		log.WithError(err).Error("Error updating category")
+		return err
+	}
+
+	log.Info("Category updated")
+	return nil
+}
+
+func (r *CategoryRepository) DeleteCategory(ctx context.Context, id uint) error {
+	log := logrus.WithContext(ctx)
+	log.Infof("Deleting category with ID: %d", id)
+
+	err := shared.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM question_categories WHERE category_id = ?", id).Error; err != nil {
+			log.WithError(err).Error("Error unlinking category questions")
+			return err
+		}
+		return tx.Delete(&models.Category{}, id).Error
+	})
+	if err != nil {
+		log.WithError(err).Error("Error deleting category")
+		return err
+	}
+
+	log.Info("Category deleted")
+	return nil
+}
+
+func (r *CategoryRepository) HasChildren(ctx context.Context, id uint) (bool, error) {
+	log := logrus.WithContext(ctx)
+	log.Infof("Checking subcategories of category ID: %d", id)
+
+	var count int64
+	err := shared.Conn(ctx, r.db).Model(&models.Category{}).Where("parent_id = ?", id).Count(&count).Error
+	if err != nil {
+		log.WithError(err).Error("Error counting subcategories")
+		return false, err
+	}
+
+	return count > 0, nil
+}
@@ -0,0 +1,16 @@
+package categoryrepository
+
+import (
+	"context"
+	"talana_prueba_tecnica/src/entity/models"
+)
+
+type CategoryRepositoryInterface interface {
+	CreateCategory(ctx context.Context, category *models.Category) error
+	FindAll(ctx context.Context) ([]models.Category, error)
+	FindByID(ctx context.Context, id uint) (*models.Category, error)
+	FindByIDs(ctx context.Context, ids []uint) ([]models.Category, error)
+	UpdateCategory(ctx context.Context, category *models.Category) error
+	DeleteCategory(ctx context.Context, id uint) error
+	HasChildren(ctx context.Context, id uint) (bool, error)
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 	"talana_prueba_tecnica/src/entity/models"
+	"talana_prueba_tecnica/src/shared"
 )
 
 type GameRepository struct {
@@ -20,8 +21,8 @@ func (r *GameRepository) GetQuestionsForTrivia(ctx context.Context, triviaID uin
 	log.Infof("GetQuestionForTrivia: triviaID: %d", triviaID)
 
 	var questions []models.Question
-	err := r.db.Preload("Options").Joins("JOIN trivia_questions ON trivia_questions.question_id = questions.id").
-		Where("trivia_questions.trivia_id = ?", triviaID).Find(&questions).Error
+	err := shared.Conn(ctx, r.db).Preload("Options").Joins("JOIN trivia_questions ON trivia_questions.question_id = questions.id").
+		Where("trivia_questions.trivia_id = ? AND questions.status = ?", triviaID, models.StatusPublished).Find(&questions).Error
 	if err != nil {
 		log.Errorf("GetQuestionForTrivia: %v", err)
 		return nil, err
@@ -35,7 +36,7 @@ func (r *GameRepository) SaveAnswer(ctx context.Context, answer *models.Answer)
 	log := logrus.WithContext(ctx)
 	log.Info("Saving answer")
 
-	if err := r.db.WithContext(ctx).Create(answer).Error; err != nil {
+	if err := shared.Conn(ctx, r.db).Create(answer).Error; err != nil {
 		log.WithError(err).Error("Error saving answer")
 		return err
 	}
@@ -49,7 +50,7 @@ func (r *GameRepository) GetRankingForTrivia(ctx context.Context, triviaID uint)
 	log.Infof("GetRankingForTrivia: triviaID: %d", triviaID)
 
 	var ranking []models.Ranking
-	err := r.db.Table("participations").Select("user_id, sum(score) as total_score").
+	err := shared.Conn(ctx, r.db).Table("participations").Select("user_id, sum(score) as total_score").
 		Where("trivia_id = ?", triviaID).Group("user_id").Order("total_score desc").Find(&ranking).Error
 
 	if err != nil {
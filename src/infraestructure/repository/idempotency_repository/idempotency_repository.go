@@ -0,0 +1,101 @@
+package idempotencyrepository
+
+import (
+	"context"
+	"talana_prueba_tecnica/src/entity/models"
+	"talana_prueba_tecnica/src/shared"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IdempotencyRepository struct {
+	db *gorm.DB
+}
+
+func NewIdempotencyRepository(db *gorm.DB) *IdempotencyRepository {
+	return &IdempotencyRepository{db: db}
+}
+
+// Reserve stores record unless the user already used its key, reporting
+// whether the record was stored.
+func (r *IdempotencyRepository) Reserve(ctx context.Context, record *models.IdempotencyRecord) (bool, error) {
+	log := logrus.WithContext(ctx)
+	log.Infof("Reserving idempotency key for user ID %d", record.UserID)
+
+	res := shared.Conn(ctx, r.db).Clauses(clause.OnConflict{DoNothing: true}).Create(record)
+	if res.Error != nil {
+		log.WithError(res.Error).Error("Error reserving idempotency key")
+		return false, res.Error
+	}
+
+	return res.RowsAffected > 0, nil
+}
+
+// TakeOver hands the reservation of the key of record to record when it was
+// made before staleBefore and never completed, reporting whether it did.
+func (r *IdempotencyRepository) TakeOver(ctx context.Context, record *models.IdempotencyRecord, staleBefore time.Time) (bool, error) {
+	log := logrus.WithContext(ctx)
+	log.Infof("Taking over stale idempotency key for user ID %d", record.UserID)
+
+	record.CreatedAt = time.Now()
+	res := shared.Conn(ctx, r.db).Model(&models.IdempotencyRecord{}).
+		Where("user_id = ? AND key = ? AND status_code = 0 AND created_at < ?", record.UserID, record.Key, staleBefore).
+		Updates(map[string]interface{}{"request_hash": record.RequestHash, "created_at": record.CreatedAt})
+	if res.Error != nil {
+		log.WithError(res.Error).Error("Error taking over idempotency key")
+		return false, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return false, nil
+	}
+
+	stored, err := r.Find(ctx, record.UserID, record.Key)
+	if err != nil {
+		return false, err
+	}
+	record.ID = stored.ID
+	return true, nil
+}
+
+func (r *IdempotencyRepository) Find(ctx context.Context, userID uint, key string) (*models.IdempotencyRecord, error) {
+	log := logrus.WithContext(ctx)
+	log.Infof("Finding idempotency key for user ID %d", userID)
+
+	var record models.IdempotencyRecord
+	err := shared.Conn(ctx, r.db).Where("user_id = ? AND key = ?", userID, key).First(&record).Error
+	if err != nil {
+		log.WithError(err).Error("Error finding idempotency key")
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+func (r *IdempotencyRepository) Complete(ctx context.Context, record *models.IdempotencyRecord) error {
+	log := logrus.WithContext(ctx)
+	log.Infof("Storing response for idempotency record ID %d", record.ID)
+
+	err := shared.Conn(ctx, r.db).Model(record).Select("status_code", "content_type", "body").Updates(record).Error
+	if err != nil {
+		log.WithError(err).Error("Error storing idempotent response")
+		return err
+	}
+
+	return nil
+}
+
+// Release deletes a reservation whose request failed so it can be retried.
+func (r *IdempotencyRepository) Release(ctx context.Context, id uint) error {
+	log := logrus.WithContext(ctx)
+	log.Infof("Releasing idempotency record ID %d", id)
+
+	if err := shared.Conn(ctx, r.db).Delete(&models.IdempotencyRecord{}, id).Error; err != nil {
+		log.WithError(err).Error("Error releasing idempotency key")
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+package idempotencyrepository
+
+import (
+	"context"
+	"talana_prueba_tecnica/src/entity/models"
+	"time"
+)
+
+type IdempotencyRepositoryInterface interface {
+	Reserve(ctx context.Context, record *models.IdempotencyRecord) (bool, error)
+	TakeOver(ctx context.Context, record *models.IdempotencyRecord, staleBefore time.Time) (bool, error)
+	Find(ctx context.Context, userID uint, key string) (*models.IdempotencyRecord, error)
+	Complete(ctx context.Context, record *models.IdempotencyRecord) error
+	Release(ctx context.Context, id uint) error
+}
@@ -0,0 +1,123 @@
+package leaderboardrepository
+
+import (
+	"context"
+	"talana_prueba_tecnica/src/entity/models"
+	"talana_prueba_tecnica/src/shared"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type LeaderboardRepository struct {
+	db *gorm.DB
+}
+
+func NewLeaderboardRepository(db *gorm.DB) *LeaderboardRepository {
+	return &LeaderboardRepository{db: db}
+}
+
+func (r *LeaderboardRepository) GetLeaderboard(ctx context.Context, filter LeaderboardFilter, limit, offset int) ([]models.Ranking, int64, error) {
+	log := logrus.WithContext(ctx)
+	log.Infof("Getting leaderboard with filter: %+v", filter)
+
+	query := r.leaderboardQuery(ctx, filter)
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Distinct("participations.user_id").Count(&total).Error; err != nil {
+		log.WithError(err).Error("Error counting leaderboard")
+		return nil, 0, err
+	}
+
+	// Scores come from the answers when filtering by difficulty, since a
+	// participation mixes questions of every difficulty.
+	scoreColumn := "participations.score"
+	correctColumn := "COALESCE(correct.correct_answers, 0)"
+	if filter.Difficulty != "" {
+		scoreColumn = "answers.points"
+		correctColumn = "CASE WHEN answers.is_correct THEN 1 ELSE 0 END"
+	} else {
+		correctAnswers := shared.Conn(ctx, r.db).Table("answers").
+			Select("participation_id, COUNT(*) AS correct_answers").
+			Where("is_correct").
+			Group("participation_id")
+		query = query.Joins("LEFT JOIN (?) AS correct ON correct.participation_id = participations.id", correctAnswers)
+	}
+
+	var rankings []models.Ranking
+	err := query.
+		Select("RANK() OVER (ORDER BY SUM(" + scoreColumn + ") DESC) AS rank, " +
+			"participations.user_id, user_models.name, " +
+			"SUM(" + scoreColumn + ") AS total_score, " +
+			"SUM(" + correctColumn + ") AS correct_answers").
+		Group("participations.user_id, user_models.name").
+		Order("total_score DESC, correct_answers DESC, participations.user_id").
+		Limit(limit).
+		Offset(offset).
+		Scan(&rankings).Error
+	if err != nil {
+		log.WithError(err).Error("Error retrieving leaderboard")
+		return nil, 0, err
+	}
+
+	log.Info("Leaderboard retrieved successfully")
+	return rankings, total, nil
+}
+
+func (r *LeaderboardRepository) leaderboardQuery(ctx context.Context, filter LeaderboardFilter) *gorm.DB {
+	query := shared.Conn(ctx, r.db).Table("participations").
+		Joins("JOIN user_models ON user_models.id = participations.user_id AND user_models.deleted_at IS NULL").
+		Where("participations.id IN (?)", shared.CountedParticipations(shared.Conn(ctx, r.db), filter.From, filter.To))
+
+	if filter.Difficulty != "" {
+		query = query.
+			Joins("JOIN answers ON answers.participation_id = participations.id").
+			Joins("JOIN questions ON questions.id = answers.question_id").
+			Where("questions.difficulty = ?", filter.Difficulty)
+	}
+	if filter.Department != "" {
+		query = query.Where("user_models.department = ?", filter.Department)
+	}
+	return query
+}
+
+func (r *LeaderboardRepository) CreateSeason(ctx context.Context, season *models.Season) error {
+	log := logrus.WithContext(ctx)
+	log.Infof("Creating season %s", season.Slug)
+
+	if err := shared.Conn(ctx, r.db).Create(season).Error; err != nil {
+		log.WithError(err).Error("Error creating season")
+		return err
+	}
+
+	log.Info("Season created")
+	return nil
+}
+
+func (r *LeaderboardRepository) FindAllSeasons(ctx context.Context) ([]models.Season, error) {
+	log := logrus.WithContext(ctx)
+	log.Info("Finding all seasons")
+
+	var seasons []models.Season
+	if err := shared.Conn(ctx, r.db).Order("starts_at DESC").Find(&seasons).Error; err != nil {
+		log.WithError(err).Error("Error finding all seasons")
+		return nil, err
+	}
+
+	log.Info("Seasons found")
+	return seasons, nil
+}
+
+func (r *LeaderboardRepository) FindSeasonBySlug(ctx context.Context, slug string) (*models.Season, error) {
+	log := logrus.WithContext(ctx)
+	log.Infof("Finding season by slug: %s", slug)
+
+	var season models.Season
+	if err := shared.Conn(ctx, r.db).Where("slug = ?", slug).First(&season).Error; err != nil {
+		log.WithError(err).Error("Error finding season by slug")
+		return nil, err
+	}
+
+	log.Info("Season found")
+	return &season, nil
+}
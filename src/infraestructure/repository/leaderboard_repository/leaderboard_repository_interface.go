@@ -0,0 +1,21 @@
+package leaderboardrepository
+
+import (
+	"context"
+	"talana_prueba_tecnica/src/entity/models"
+	"time"
+)
+
+type LeaderboardFilter struct {
+	From       *time.Time
+	To         *time.Time
+	Department string
+	Difficulty string
+}
+
+type LeaderboardRepositoryInterface interface {
+	GetLeaderboard(ctx context.Context, filter LeaderboardFilter, limit, offset int) ([]models.Ranking, int64, error)
+	CreateSeason(ctx context.Context, season *models.Season) error
+	FindAllSeasons(ctx context.Context) ([]models.Season, error)
+	FindSeasonBySlug(ctx context.Context, slug string) (*models.Season, error)
+}
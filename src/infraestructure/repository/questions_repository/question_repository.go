@@ -4,11 +4,17 @@ import (
 	"context"
 	"errors"
 	"talana_prueba_tecnica/src/entity/models"
+	"talana_prueba_tecnica/src/shared"
 
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ErrQuestionPlayed is returned when purging a question that game sessions
+// served, so their answers would be lost.
+var ErrQuestionPlayed = errors.New("question was played")
+
 type QuestionRepository struct {
 	db *gorm.DB
 }
@@ -23,7 +29,7 @@ func (q *QuestionRepository) CreateQuestion(ctx context.Context, question *model
 	log := logrus.WithContext(ctx)
 	log.Info("creating question in repository")
 
-	return q.db.Transaction(func(tx *gorm.DB) error {
+	return shared.Conn(ctx, q.db).Transaction(func(tx *gorm.DB) error {
 		for _, option := range question.Options {
 			if option.Text == "" {
 				// esto le solicite ayuda a claude, tenia respuestas duplicadas y no sabia como solucionarlo
@@ -37,23 +43,18 @@ func (q *QuestionRepository) CreateQuestion(ctx context.Context, question *model
 			return err
 		}
 
-		if question.CorrectOption > 0 && len(question.Options) > 0 {
-			if int(question.CorrectOption) >= len(question.Options) {
-				log.Errorf("the correct option index is invalid")
-				return errors.New("the correct option index is invalid")
-			}
-
-			question.CorrectOption = question.Options[question.CorrectOption-1].ID
-			if err := tx.Save(question).Error; err != nil {
-				log.Error("Error updating question")
-				return err
-			}
-		}
 		return nil
 	})
 }
 
-func (q *QuestionRepository) FindAll(ctx context.Context) ([]models.Question, error) {
+// categoryTree selects the ID of a category and of all its subcategories.
+const categoryTree = `WITH RECURSIVE tree AS (
+	SELECT id FROM categories WHERE id = ?
+	UNION ALL
+	SELECT categories.id FROM categories JOIN tree ON categories.parent_id = tree.id
+) SELECT id FROM tree`
+
+func (q *QuestionRepository) FindAll(ctx context.Context, filter QuestionFilter) ([]models.Question, error) {
 	log := logrus.WithContext(ctx)
 	log.Println("finding all questions")
 
@@ -61,7 +62,23 @@ func (q *QuestionRepository) FindAll(ctx context.Context) ([]models.Question, er
 
 	log.Info("finding all questions")
 
-	res := q.db.WithContext(ctx).Preload("Options").Find(&questions)
+	query := shared.Conn(ctx, q.db).Preload("Options").Preload("Categories").Preload("Tags")
+	if filter.CategoryID != 0 {
+		query = query.Where("questions.id IN (SELECT question_id FROM question_categories WHERE category_id IN (?))",
+			gorm.Expr(categoryTree, filter.CategoryID))
+	}
+	if filter.Tag != "" {
+		query = query.Where("questions.id IN (SELECT question_id FROM question_tags JOIN tags ON tags.id = question_tags.tag_id WHERE tags.name = ?)",
+			filter.Tag)
+	}
+	if filter.Difficulty != "" {
+		query = query.Where("questions.difficulty = ?", filter.Difficulty)
+	}
+	if filter.Status != "" {
+		query = query.Where("questions.status = ?", filter.Status)
+	}
+
+	res := query.Order("questions.id").Find(&questions)
 	if res.Error != nil {
 		log.Error("Error finding all questions")
 		return nil, res.Error
@@ -79,7 +96,7 @@ func (q *QuestionRepository) FindByID(ctx context.Context, id uint) (*models.Que
 
 	log.Info("finding question by id")
 
-	res := q.db.WithContext(ctx).Preload("Options").First(&question, id)
+	res := shared.Conn(ctx, q.db).Preload("Options").Preload("Categories").Preload("Tags").First(&question, id)
 	if res.Error != nil {
 		log.Error("Error finding question by id")
 		return nil, res.Error
@@ -95,7 +112,7 @@ func (q *QuestionRepository) FullTextSearch(ctx context.Context, query string) (
 
 	var questions []models.Question
 
-	err := q.db.Preload("Options").
+	err := shared.Conn(ctx, q.db).Preload("Options").Preload("Categories").Preload("Tags").
 		Select("questions.*, "+
 			"ts_rank(to_tsvector('english', question), plainto_tsquery(?)) as question_rank, "+
 			"COALESCE((SELECT MAX(ts_rank(to_tsvector('english', text), plainto_tsquery(?))) "+
@@ -120,43 +137,178 @@ func (q *QuestionRepository) UpdateQuestion(ctx context.Context, question *model
 	log := logrus.WithContext(ctx)
 	log.Info("Updating question")
 
-	err := q.db.Transaction(func(tx *gorm.DB) error {
-		if err := tx.Model(&models.Question{}).Where("id = ?", id).Updates(question).Error; err != nil {
-			log.WithError(err).Error("Error updating question")
-			return err
-		}
+	err := shared.Conn(ctx, q.db).Model(&models.Question{}).Where("id = ?", id).
+		Select("question", "type", "correct_option", "partial_credit", "numeric_answer", "tolerance",
+			"difficulty", "points", "time_limit_seconds", "explanation", "reference_url", "revision").
+		Updates(question).Error
+	if err != nil {
+		log.WithError(err).Error("Error updating question")
+		return err
+	}
 
-		log.Info("Question updated successfully")
-		return nil
-	})
+	log.Info("Question updated successfully")
+	return nil
+}
 
+func (q *QuestionRepository) UpdateStatus(ctx context.Context, id uint, status string) error {
+	log := logrus.WithContext(ctx)
+	log.Infof("Updating status of question ID %d to %s", id, status)
+
+	err := shared.Conn(ctx, q.db).Model(&models.Question{}).Where("id = ?", id).Update("status", status).Error
 	if err != nil {
-		log.WithError(err).Error("Transaction failed while updating question")
+		log.WithError(err).Error("Error updating question status")
+		return err
+	}
+
+	log.Info("Question status updated successfully")
+	return nil
+}
+
+func (q *QuestionRepository) ReplaceOptions(ctx context.Context, questionID uint, options []models.Option) error {
+	log := logrus.WithContext(ctx)
+	log.Infof("Replacing options of question ID %d", questionID)
+
+	tx := shared.Conn(ctx, q.db)
+	if err := tx.Where("question_id = ?", questionID).Delete(&models.Option{}).Error; err != nil {
+		log.WithError(err).Error("Error deleting question options")
+		return err
+	}
+
+	for i := range options {
+		options[i].QuestionID = questionID
+	}
+	if err := tx.Create(&options).Error; err != nil {
+		log.WithError(err).Error("Error creating question options")
+		return err
+	}
+
+	log.Info("Question options replaced successfully")
+	return nil
+}
+
+func (q *QuestionRepository) ReplaceTaxonomy(ctx context.Context, questionID uint, categories []models.Category, tags []models.Tag) error {
+	log := logrus.WithContext(ctx)
+	log.Infof("Replacing categories and tags of question ID %d", questionID)
+
+	question := &models.Question{ID: questionID}
+	tx := shared.Conn(ctx, q.db)
+	if err := tx.Model(question).Association("Categories").Replace(categories); err != nil {
+		log.WithError(err).Error("Error replacing question categories")
+		return err
+	}
+	if err := tx.Model(question).Association("Tags").Replace(tags); err != nil {
+		log.WithError(err).Error("Error replacing question tags")
+		return err
+	}
+
+	log.Info("Question categories and tags replaced successfully")
+	return nil
+}
+
+func (q *QuestionRepository) CreateRevision(ctx context.Context, revision *models.QuestionRevision) error {
+	log := logrus.WithContext(ctx)
+	log.Infof("Creating revision %d of question ID %d", revision.Revision, revision.QuestionID)
+
+	if err := shared.Conn(ctx, q.db).Create(revision).Error; err != nil {
+		log.WithError(err).Error("Error creating question revision")
 		return err
 	}
 
-	log.Info("Transaction completed successfully")
+	log.Info("Question revision created successfully")
 	return nil
 }
 
+func (q *QuestionRepository) FindRevisions(ctx context.Context, questionID uint) ([]models.QuestionRevision, error) {
+	log := logrus.WithContext(ctx)
+	log.Infof("Finding revisions of question ID %d", questionID)
+
+	var revisions []models.QuestionRevision
+	err := shared.Conn(ctx, q.db).Where("question_id = ?", questionID).Order("revision").Find(&revisions).Error
+	if err != nil {
+		log.WithError(err).Error("Error finding question revisions")
+		return nil, err
+	}
+
+	log.Infof("Found %d question revisions", len(revisions))
+	return revisions, nil
+}
+
+// DeleteQuestion soft deletes the question. Its options, taxonomy, trivias
+// and answers are kept so it can be restored.
 func (q *QuestionRepository) DeleteQuestion(ctx context.Context, id uint) error {
 	log := logrus.WithContext(ctx)
 	log.Info("deleting question")
 
-	err := q.db.Transaction(func(tx *gorm.DB) error {
-		if err := tx.Delete(&models.Question{}, id).Error; err != nil {
-			log.Error("Error deleting question")
+	if err := shared.Conn(ctx, q.db).Delete(&models.Question{}, id).Error; err != nil {
+		log.WithError(err).Error("Error deleting question")
+		return err
+	}
+
+	log.Info("question deleted")
+	return nil
+}
+
+// RestoreQuestion undoes DeleteQuestion, returning gorm.ErrRecordNotFound
+// unless the question is deleted.
+func (q *QuestionRepository) RestoreQuestion(ctx context.Context, id uint) error {
+	log := logrus.WithContext(ctx)
+	log.Infof("restoring question ID %d", id)
+
+	res := shared.Conn(ctx, q.db).Unscoped().Model(&models.Question{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if res.Error != nil {
+		log.WithError(res.Error).Error("Error restoring question")
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	log.Info("question restored")
+	return nil
+}
+
+// PurgeQuestion permanently removes a deleted question with its options,
+// revisions, taxonomy and trivia links. It returns gorm.ErrRecordNotFound
+// unless the question is deleted, and ErrQuestionPlayed once a game session
+// served it.
+func (q *QuestionRepository) PurgeQuestion(ctx context.Context, id uint) error {
+	log := logrus.WithContext(ctx)
+	log.Infof("purging question ID %d", id)
+
+	err := shared.Conn(ctx, q.db).Transaction(func(tx *gorm.DB) error {
+		var question models.Question
+		// Locking the question holds back answers to it until the purge ends.
+		err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("deleted_at IS NOT NULL").First(&question, id).Error
+		if err != nil {
 			return err
 		}
 
-		return nil
-	})
+		var played bool
+		err = tx.Raw("SELECT EXISTS (SELECT 1 FROM answers WHERE question_id = ?) OR "+
+			"EXISTS (SELECT 1 FROM session_questions WHERE question_id = ?)", id, id).Scan(&played).Error
+		if err != nil {
+			return err
+		}
+		if played {
+			return ErrQuestionPlayed
+		}
 
+		if err := tx.Where("question_id = ?", id).Delete(&models.QuestionRevision{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM trivia_questions WHERE question_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Select("Options", "Categories", "Tags").Delete(&question).Error
+	})
 	if err != nil {
-		log.WithError(err).Error("Error deleting question")
+		log.WithError(err).Error("Error purging question")
 		return err
 	}
 
-	log.Info("question deleted")
+	log.Info("question purged")
 	return nil
 }
@@ -5,11 +5,27 @@ import (
 	"talana_prueba_tecnica/src/entity/models"
 )
 
+// QuestionFilter narrows FindAll; zero values match every question.
+// CategoryID also matches the questions of its subcategories.
+type QuestionFilter struct {
+	CategoryID uint
+	Tag        string
+	Difficulty string
+	Status     string
+}
+
 type QuestionRepositoryInterface interface {
 	CreateQuestion(ctx context.Context, question *models.Question) error
-	FindAll(ctx context.Context) ([]models.Question, error)
+	FindAll(ctx context.Context, filter QuestionFilter) ([]models.Question, error)
 	FindByID(ctx context.Context, id uint) (*models.Question, error)
 	FullTextSearch(ctx context.Context, query string) ([]models.Question, error)
 	UpdateQuestion(ctx context.Context, question *models.Question, id uint) error
+	UpdateStatus(ctx context.Context, id uint, status string) error
+	ReplaceOptions(ctx context.Context, questionID uint, options []models.Option) error
+	ReplaceTaxonomy(ctx context.Context, questionID uint, categories []models.Category, tags []models.Tag) error
+	CreateRevision(ctx context.Context, revision *models.QuestionRevision) error
+	FindRevisions(ctx context.Context, questionID uint) ([]models.QuestionRevision, error)
 	DeleteQuestion(ctx context.Context, id uint) error
+	RestoreQuestion(ctx context.Context, id uint) error
+	PurgeQuestion(ctx context.Context, id uint) error
 }
@@ -0,0 +1,47 @@
+package reviewrepository
+
+import (
+	"context"
+	"talana_prueba_tecnica/src/entity/models"
+	"talana_prueba_tecnica/src/shared"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type ReviewRepository struct {
+	db *gorm.DB
+}
+
+func NewReviewRepository(db *gorm.DB) *ReviewRepository {
+	return &ReviewRepository{db: db}
+}
+
+func (r *ReviewRepository) CreateEvent(ctx context.Context, event *models.ReviewEvent) error {
+	log := logrus.WithContext(ctx)
+	log.Infof("Creating review event for %s ID %d", event.ContentType, event.ContentID)
+
+	if err := shared.Conn(ctx, r.db).Create(event).Error; err != nil {
+		log.WithError(err).Error("Error creating review event")
+		return err
+	}
+
+	log.Info("Review event created")
+	return nil
+}
+
+func (r *ReviewRepository) FindEvents(ctx context.Context, contentType string, contentID uint) ([]models.ReviewEvent, error) {
+	log := logrus.WithContext(ctx)
+	log.Infof("Finding review events for %s ID %d", contentType, contentID)
+
+	var events []models.ReviewEvent
+	err := shared.Conn(ctx, r.db).Where("content_type = ? AND content_id = ?", contentType, contentID).
+		Order("created_at, id").Find(&events).Error
+	if err != nil {
+		log.WithError(err).Error("Error finding review events")
+		return nil, err
+	}
+
+	log.Infof("Found %d review events", len(events))
+	return events, nil
+}
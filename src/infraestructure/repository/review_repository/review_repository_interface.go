@@ -0,0 +1,11 @@
+package reviewrepository
+
+import (
+	"context"
+	"talana_prueba_tecnica/src/entity/models"
+)
+
+type ReviewRepositoryInterface interface {
+	CreateEvent(ctx context.Context, event *models.ReviewEvent) error
+	FindEvents(ctx context.Context, contentType string, contentID uint) ([]models.ReviewEvent, error)
+}
@@ -0,0 +1,182 @@
+package sessionrepository
+
+import (
+	"context"
+	"talana_prueba_tecnica/src/entity/models"
+	"talana_prueba_tecnica/src/shared"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type SessionRepository struct {
+	db *gorm.DB
+}
+
+func NewSessionRepository(db *gorm.DB) *SessionRepository {
+	return &SessionRepository{db: db}
+}
+
+func (r *SessionRepository) CreateSession(ctx context.Context, session *models.GameSession) error {
+	log := logrus.WithContext(ctx)
+	log.Infof("Creating session for trivia ID %d and user ID %d", session.TriviaID, session.UserID)
+
+	return shared.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&session.Participation).Error; err != nil {
+			log.WithError(err).Error("Error creating participation for session")
+			return err
+		}
+
+		session.ParticipationID = session.Participation.ID
+		if err := tx.Omit("Participation").Create(session).Error; err != nil {
+			log.WithError(err).Error("Error creating session")
+			return err
+		}
+
+		log.Info("Session created")
+		return nil
+	})
+}
+
+func (r *SessionRepository) FindByID(ctx context.Context, id uint) (*models.GameSession, error) {
+	log := logrus.WithContext(ctx)
+	log.Infof("Finding session by ID: %d", id)
+
+	var session models.GameSession
+	err := shared.Conn(ctx, r.db).
+		Preload("Participation").
+		Preload("Participation.Answers").
+		Preload("Questions", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC")
+		}).
+		First(&session, id).Error
+	if err != nil {
+		log.WithError(err).Error("Error finding session by ID")
+		return nil, err
+	}
+
+	log.Info("Session found")
+	return &session, nil
+}
+
+func (r *SessionRepository) FindActive(ctx context.Context, triviaID, userID uint) (*models.GameSession, error) {
+	log := logrus.WithContext(ctx)
+	log.Infof("Finding active session for trivia ID %d and user ID %d", triviaID, userID)
+
+	var session models.GameSession
+	err := shared.Conn(ctx, r.db).
+		Preload("Participation").
+		Preload("Participation.Answers").
+		Preload("Questions", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position ASC")
+		}).
+		Where("trivia_id = ? AND user_id = ? AND status = ?", triviaID, userID, models.SessionStatusActive).
+		Order("started_at DESC").
+		First(&session).Error
+	if err != nil {
+		log.WithError(err).Info("No active session found")
+		return nil, err
+	}
+
+	log.Info("Active session found")
+	return &session, nil
+}
+
+func (r *SessionRepository) MarkServed(ctx context.Context, sessionQuestion *models.SessionQuestion) error {
+	log := logrus.WithContext(ctx)
+	log.Infof("Marking question ID %d as served in session ID %d", sessionQuestion.QuestionID, sessionQuestion.SessionID)
+
+	now := time.Now()
+	err := shared.Conn(ctx, r.db).Model(sessionQuestion).
+		Updates(map[string]interface{}{"served_at": now, "question_revision": sessionQuestion.QuestionRevision}).Error
+	if err != nil {
+		log.WithError(err).Error("Error marking question as served")
+		return err
+	}
+
+	sessionQuestion.ServedAt = &now
+	log.Info("Question marked as served")
+	return nil
+}
+
+// RecordAnswer saves answer to sessionQuestion and adds its points to the
+// score of the session. It returns gorm.ErrRecordNotFound when the question
+// was already answered, so concurrent answers are recorded only once.
+func (r *SessionRepository) RecordAnswer(ctx context.Context, session *models.GameSession, sessionQuestion *models.SessionQuestion, answer *models.Answer) error {
+	log := logrus.WithContext(ctx)
+	log.Infof("Recording answer for question ID %d in session ID %d", sessionQuestion.QuestionID, session.ID)
+
+	return shared.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		if answer.AnsweredAt != nil {
+			now = *answer.AnsweredAt
+		}
+
+		res := tx.Model(&models.SessionQuestion{}).
+			Where("id = ? AND answered_at IS NULL", sessionQuestion.ID).
+			Update("answered_at", now)
+		if res.Error != nil {
+			log.WithError(res.Error).Error("Error marking question as answered")
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			log.Errorf("Question ID %d already answered in session ID %d", sessionQuestion.QuestionID, session.ID)
+			return gorm.ErrRecordNotFound
+		}
+		sessionQuestion.AnsweredAt = &now
+
+		answer.ParticipationID = session.ParticipationID
+		if err := tx.Create(answer).Error; err != nil {
+			log.WithError(err).Error("Error saving answer")
+			return err
+		}
+
+		if err := tx.Model(&session.Participation).Update("score", gorm.Expr("score + ?", answer.Points)).Error; err != nil {
+			log.WithError(err).Error("Error updating participation score")
+			return err
+		}
+
+		if session.Status != models.SessionStatusActive {
+			if err := finishSession(tx, session, now); err != nil {
+				log.WithError(err).Error("Error finishing session")
+				return err
+			}
+		}
+
+		log.Info("Answer recorded")
+		return nil
+	})
+}
+
+func (r *SessionRepository) ExpireSession(ctx context.Context, session *models.GameSession) error {
+	log := logrus.WithContext(ctx)
+	log.Infof("Expiring session ID %d", session.ID)
+
+	err := shared.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		return finishSession(tx, session, time.Now())
+	})
+	if err != nil {
+		log.WithError(err).Error("Error expiring session")
+		return err
+	}
+
+	log.Info("Session expired")
+	return nil
+}
+
+func finishSession(tx *gorm.DB, session *models.GameSession, finishedAt time.Time) error {
+	if err := tx.Model(session).Updates(map[string]interface{}{
+		"status":      session.Status,
+		"finished_at": finishedAt,
+	}).Error; err != nil {
+		return err
+	}
+	session.FinishedAt = &finishedAt
+
+	if err := tx.Model(&session.Participation).Update("finished_at", finishedAt).Error; err != nil {
+		return err
+	}
+	session.Participation.FinishedAt = &finishedAt
+	return nil
+}
@@ -0,0 +1,15 @@
+package sessionrepository
+
+import (
+	"context"
+	"talana_prueba_tecnica/src/entity/models"
+)
+
+type SessionRepositoryInterface interface {
+	CreateSession(ctx context.Context, session *models.GameSession) error
+	FindByID(ctx context.Context, id uint) (*models.GameSession, error)
+	FindActive(ctx context.Context, triviaID, userID uint) (*models.GameSession, error)
+	MarkServed(ctx context.Context, sessionQuestion *models.SessionQuestion) error
+	RecordAnswer(ctx context.Context, session *models.GameSession, sessionQuestion *models.SessionQuestion, answer *models.Answer) error
+	ExpireSession(ctx context.Context, session *models.GameSession) error
+}
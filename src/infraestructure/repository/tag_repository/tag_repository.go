@@ -0,0 +1,120 @@
+package tagrepository
+
+import (
+	"context"
+	"talana_prueba_tecnica/src/entity/models"
+	"talana_prueba_tecnica/src/shared"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TagRepository struct {
+	db *gorm.DB
+}
+
+func NewTagRepository(db *gorm.DB) *TagRepository {
+	return &TagRepository{db: db}
+}
+
+func (r *TagRepository) CreateTag(ctx context.Context, tag *models.Tag) error {
+	log := logrus.WithContext(ctx)
+	log.Info("Creating tag")
+
+	if err := shared.Conn(ctx, r.db).Create(tag).Error; err != nil {
+		log.WithError(err).Error("Error creating tag")
+		return err
+	}
+
+	log.Info("Tag created")
+	return nil
+}
+
+func (r *TagRepository) FindAll(ctx context.Context) ([]models.Tag, error) {
+	log := logrus.WithContext(ctx)
+	log.Info("Finding all tags")
+
+	var tags []models.Tag
+	if err := shared.Conn(ctx, r.db).Order("name").Find(&tags).Error; err != nil {
+		log.WithError(err).Error("Error finding all tags")
+		return nil, err
+	}
+
+	log.Info("Tags found")
+	return tags, nil
+}
+
+func (r *TagRepository) FindByID(ctx context.Context, id uint) (*models.Tag, error) {
+	log := logrus.WithContext(ctx)
+	log.Infof("Finding tag by ID: %d", id)
+
+	var tag models.Tag
+	if err := shared.Conn(ctx, r.db).First(&tag, id).Error; err != nil {
+		log.WithError(err).Error("Error finding tag by ID")
+		return nil, err
+	}
+
+	return &tag, nil
+}
+
+// FindOrCreate returns the tags named names, creating the missing ones.
+func (r *TagRepository) FindOrCreate(ctx context.Context, names []string) ([]models.Tag, error) {
+	log := logrus.WithContext(ctx)
+	log.Infof("Resolving tags %v", names)
+
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	tx := shared.Conn(ctx, r.db)
+	tags := make([]models.Tag, 0, len(names))
+	for _, name := range names {
+		tags = append(tags, models.Tag{Name: name})
+	}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&tags).Error; err != nil {
+		log.WithError(err).Error("Error creating tags")
+		return nil, err
+	}
+
+	tags = nil
+	if err := tx.Where("name IN ?", names).Order("name").Find(&tags).Error; err != nil {
+		log.WithError(err).Error("Error finding tags")
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+func (r *TagRepository) UpdateTag(ctx context.Context, tag *models.Tag) error {
+	log := logrus.WithContext(ctx)
+	log.Infof("Updating tag with ID: %d", tag.ID)
+
+	if err := shared.Conn(ctx, r.db).Model(tag).Update("name", tag.Name).Error; err != nil {
+		log.WithError(err).Error("Error updating tag")
+		return err
+	}
+
+	log.Info("Tag updated")
+	return nil
+}
+
+func (r *TagRepository) DeleteTag(ctx context.Context, id uint) error {
+	log := logrus.WithContext(ctx)
+	log.Infof("Deleting tag with ID: %d", id)
+
+	err := shared.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM question_tags WHERE tag_id = ?", id).Error; err != nil {
+			log.WithError(err).Error("Error unlinking tag questions")
+			return err
+		}
+		return tx.Delete(&models.Tag{}, id).Error
+	})
+	if err != nil {
+		log.WithError(err).Error("Error deleting tag")
+		return err
+	}
+
+	log.Info("Tag deleted")
+	return nil
+}
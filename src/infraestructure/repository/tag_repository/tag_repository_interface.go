@@ -0,0 +1,15 @@
+package tagrepository
+
+import (
+	"context"
+	"talana_prueba_tecnica/src/entity/models"
+)
+
+type TagRepositoryInterface interface {
+	CreateTag(ctx context.Context, tag *models.Tag) error
+	FindAll(ctx context.Context) ([]models.Tag, error)
+	FindByID(ctx context.Context, id uint) (*models.Tag, error)
+	FindOrCreate(ctx context.Context, names []string) ([]models.Tag, error)
+	UpdateTag(ctx context.Context, tag *models.Tag) error
+	DeleteTag(ctx context.Context, id uint) error
+}
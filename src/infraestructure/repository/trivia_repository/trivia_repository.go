@@ -3,6 +3,7 @@ package triviarepository
 import (
 	"context"
 	"talana_prueba_tecnica/src/entity/models"
+	"talana_prueba_tecnica/src/shared"
 
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
@@ -20,24 +21,25 @@ func (r *TriviaRepository) CreateTrivia(ctx context.Context, trivia *models.Triv
 	log := logrus.WithContext(ctx)
 	log.Info("Creating trivia")
 
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		if err := tx.Create(trivia).Error; err != nil {
-			log.WithError(err).Error("Error creating trivia")
-			return err
-		}
+	// Callers run this inside a unit of work so the trivia and its
+	// associations are written atomically.
+	tx := shared.Conn(ctx, r.db)
+	if err := tx.Create(trivia).Error; err != nil {
+		log.WithError(err).Error("Error creating trivia")
+		return err
+	}
 
-		if err := tx.Model(trivia).Association("Questions").Replace(trivia.Questions); err != nil {
-			log.WithError(err).Error("Error associating questions")
-			return err
-		}
+	if err := tx.Model(trivia).Association("Questions").Replace(trivia.Questions); err != nil {
+		log.WithError(err).Error("Error associating questions")
+		return err
+	}
 
-		if err := tx.Model(trivia).Association("Users").Replace(trivia.Users); err != nil {
-			log.WithError(err).Error("Error associating users")
-			return err
-		}
+	if err := tx.Model(trivia).Association("Users").Replace(trivia.Users); err != nil {
+		log.WithError(err).Error("Error associating users")
+		return err
+	}
 
-		return nil
-	})
+	return nil
 }
 
 func (r *TriviaRepository) FindAll(ctx context.Context) ([]models.Trivia, error) {
@@ -45,7 +47,7 @@ func (r *TriviaRepository) FindAll(ctx context.Context) ([]models.Trivia, error)
 	log.Info("Finding all trivias")
 
 	var trivias []models.Trivia
-	err := r.db.Preload("Questions").Preload("Users").Preload("Questions.Options").Find(&trivias)
+	err := shared.Conn(ctx, r.db).Preload("Questions").Preload("Users").Preload("Questions.Options").Find(&trivias)
 	if err.Error != nil {
 		log.WithError(err.Error).Error("Error finding all trivias")
 		return nil, err.Error
@@ -60,7 +62,7 @@ func (r *TriviaRepository) FindByID(ctx context.Context, id uint) (models.Trivia
 	log.Infof("Finding trivia by ID: %d", id)
 
 	var trivia models.Trivia
-	err := r.db.WithContext(ctx).
+	err := shared.Conn(ctx, r.db).
 		Preload("Questions").
 		Preload("Questions.Options").
 		Preload("Users").
@@ -75,11 +77,34 @@ func (r *TriviaRepository) FindByID(ctx context.Context, id uint) (models.Trivia
 	return trivia, nil
 }
 
+// FindByIDUnscoped is FindByID including deleted trivias and questions, for
+// showing past participations.
+func (r *TriviaRepository) FindByIDUnscoped(ctx context.Context, id uint) (models.Trivia, error) {
+	log := logrus.WithContext(ctx)
+	log.Infof("Finding trivia by ID including deleted: %d", id)
+
+	var trivia models.Trivia
+	err := shared.Conn(ctx, r.db).Unscoped().
+		Preload("Questions").
+		Preload("Questions.Options").
+		First(&trivia, id).Error
+	if err != nil {
+		log.WithError(err).Error("Error finding trivia by ID")
+		return models.Trivia{}, err
+	}
+
+	log.Info("Trivia found")
+	return trivia, nil
+}
+
 func (r *TriviaRepository) UpdateTrivia(ctx context.Context, trivia *models.Trivia, id uint) error {
 	log := logrus.WithContext(ctx)
 	log.Infof("Updating trivia with ID: %d", id)
 
-	err := r.db.Model(&models.Trivia{}).Where("id = ?", id).Updates(trivia)
+	err := shared.Conn(ctx, r.db).Model(&models.Trivia{}).Where("id = ?", id).
+		Select("name", "description", "scoring_strategy", "time_limit_seconds", "max_attempts", "cooldown_seconds",
+			"ranking_policy", "require_all_answers", "shuffle_questions", "shuffle_options", "question_pool_size").
+		Updates(trivia)
 	if err.Error != nil {
 		log.WithError(err.Error).Error("Error updating trivia")
 		return err.Error
@@ -89,11 +114,27 @@ func (r *TriviaRepository) UpdateTrivia(ctx context.Context, trivia *models.Triv
 	return nil
 }
 
+func (r *TriviaRepository) UpdateStatus(ctx context.Context, id uint, status string) error {
+	log := logrus.WithContext(ctx)
+	log.Infof("Updating status of trivia ID %d to %s", id, status)
+
+	err := shared.Conn(ctx, r.db).Model(&models.Trivia{}).Where("id = ?", id).Update("status", status).Error
+	if err != nil {
+		log.WithError(err).Error("Error updating trivia status")
+		return err
+	}
+
+	log.Info("Trivia status updated")
+	return nil
+}
+
+// DeleteTrivia soft deletes the trivia. Its questions, users and
+// participations are kept so it can be restored.
 func (r *TriviaRepository) DeleteTrivia(ctx context.Context, id uint) error {
 	log := logrus.WithContext(ctx)
 	log.Infof("Deleting trivia with ID: %d", id)
 
-	err := r.db.Delete(&models.Trivia{}, id)
+	err := shared.Conn(ctx, r.db).Delete(&models.Trivia{}, id)
 	if err.Error != nil {
 		log.WithError(err.Error).Error("Error deleting trivia")
 		return err.Error
@@ -103,11 +144,60 @@ func (r *TriviaRepository) DeleteTrivia(ctx context.Context, id uint) error {
 	return nil
 }
 
+// RestoreTrivia undoes DeleteTrivia, returning gorm.ErrRecordNotFound
+// unless the trivia is deleted.
+func (r *TriviaRepository) RestoreTrivia(ctx context.Context, id uint) error {
+	log := logrus.WithContext(ctx)
+	log.Infof("Restoring trivia with ID: %d", id)
+
+	res := shared.Conn(ctx, r.db).Unscoped().Model(&models.Trivia{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if res.Error != nil {
+		log.WithError(res.Error).Error("Error restoring trivia")
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	log.Info("Trivia restored")
+	return nil
+}
+
+// PurgeTrivia permanently removes a deleted trivia with its participations,
+// answers and game sessions. Its questions are kept. It returns
+// gorm.ErrRecordNotFound unless the trivia is deleted.
+func (r *TriviaRepository) PurgeTrivia(ctx context.Context, id uint) error {
+	log := logrus.WithContext(ctx)
+	log.Infof("Purging trivia with ID: %d", id)
+
+	err := shared.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var trivia models.Trivia
+		if err := tx.Unscoped().Where("deleted_at IS NOT NULL").First(&trivia, id).Error; err != nil {
+			return err
+		}
+
+		// Answers and game sessions cascade with their participation.
+		if err := tx.Where("trivia_id = ?", id).Delete(&models.Participation{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Select("Questions", "Users").Delete(&trivia).Error
+	})
+	if err != nil {
+		log.WithError(err).Error("Error purging trivia")
+		return err
+	}
+
+	log.Info("Trivia purged")
+	return nil
+}
+
 func (r *TriviaRepository) SaveParticipation(ctx context.Context, participation *models.Participation) error {
 	log := logrus.WithContext(ctx)
 	log.Info("Saving participation")
 
-	err := r.db.Create(participation)
+	err := shared.Conn(ctx, r.db).Create(participation)
 	if err.Error != nil {
 		log.WithError(err.Error).Error("Error saving participation")
 		return err.Error
@@ -117,12 +207,32 @@ func (r *TriviaRepository) SaveParticipation(ctx context.Context, participation
 	return nil
 }
 
+func (r *TriviaRepository) FindParticipationByIdempotencyKey(ctx context.Context, userID uint, key string) (models.Participation, error) {
+	log := logrus.WithContext(ctx)
+	log.Infof("Finding participation by idempotency key for user ID: %d", userID)
+
+	var participation models.Participation
+	err := shared.Conn(ctx, r.db).Preload("Answers").
+		Where("user_id = ? AND idempotency_key = ?", userID, key).
+		First(&participation).Error
+	if err != nil {
+		log.WithError(err).Error("Error finding participation by idempotency key")
+		return models.Participation{}, err
+	}
+
+	return participation, nil
+}
+
 func (r *TriviaRepository) GetUserScore(ctx context.Context, triviaID, userID uint) (models.Participation, error) {
 	log := logrus.WithContext(ctx)
 	log.Infof("Getting user score for trivia ID: %d and user ID: %d", triviaID, userID)
 
 	var participation models.Participation
-	err := r.db.Preload("Answers").Where("trivia_id = ? AND user_id = ?", triviaID, userID).First(&participation).Error
+	err := shared.Conn(ctx, r.db).Preload("Answers", func(db *gorm.DB) *gorm.DB {
+		return db.Order("answers.id")
+	}).Where("trivia_id = ? AND user_id = ? AND finished_at IS NOT NULL", triviaID, userID).
+		Order("finished_at DESC").
+		First(&participation).Error
 	if err != nil {
 		log.WithError(err).Error("Error getting user score")
 		return models.Participation{}, err
@@ -132,12 +242,80 @@ func (r *TriviaRepository) GetUserScore(ctx context.Context, triviaID, userID ui
 	return participation, nil
 }
 
+func (r *TriviaRepository) CountAttempts(ctx context.Context, triviaID, userID uint) (int64, error) {
+	log := logrus.WithContext(ctx)
+	log.Infof("Counting attempts for trivia ID: %d and user ID: %d", triviaID, userID)
+
+	var count int64
+	err := shared.Conn(ctx, r.db).Model(&models.Participation{}).Where("trivia_id = ? AND user_id = ?", triviaID, userID).Count(&count).Error
+	if err != nil {
+		log.WithError(err).Error("Error counting attempts")
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *TriviaRepository) FindLastAttempt(ctx context.Context, triviaID, userID uint) (models.Participation, error) {
+	log := logrus.WithContext(ctx)
+	log.Infof("Finding last attempt for trivia ID: %d and user ID: %d", triviaID, userID)
+
+	var participation models.Participation
+	err := shared.Conn(ctx, r.db).Where("trivia_id = ? AND user_id = ?", triviaID, userID).Order("id DESC").First(&participation).Error
+	if err != nil {
+		log.WithError(err).Error("Error finding last attempt")
+		return models.Participation{}, err
+	}
+
+	return participation, nil
+}
+
+func (r *TriviaRepository) FindParticipationsByUser(ctx context.Context, userID uint) ([]models.Participation, error) {
+	log := logrus.WithContext(ctx)
+	log.Infof("Finding participations for user ID: %d", userID)
+
+	var participations []models.Participation
+	err := shared.Conn(ctx, r.db).Preload("Answers", func(db *gorm.DB) *gorm.DB {
+		return db.Order("answers.id")
+	}).Where("user_id = ? AND finished_at IS NOT NULL", userID).
+		Order("finished_at DESC").
+		Find(&participations).Error
+	if err != nil {
+		log.WithError(err).Error("Error finding participations for user")
+		return nil, err
+	}
+
+	log.Info("User participations found successfully")
+	return participations, nil
+}
+
+// FindAnsweredQuestionIDs returns the questions any of the users has
+// answered, in any trivia.
+func (r *TriviaRepository) FindAnsweredQuestionIDs(ctx context.Context, userIDs []uint) ([]uint, error) {
+	log := logrus.WithContext(ctx)
+	log.Infof("Finding questions answered by users: %v", userIDs)
+
+	var questionIDs []uint
+	err := shared.Conn(ctx, r.db).Model(&models.Answer{}).
+		Joins("JOIN participations ON participations.id = answers.participation_id").
+		Where("participations.user_id IN ?", userIDs).
+		Distinct().
+		Pluck("answers.question_id", &questionIDs).Error
+	if err != nil {
+		log.WithError(err).Error("Error finding answered questions")
+		return nil, err
+	}
+
+	return questionIDs, nil
+}
+
 func (r *TriviaRepository) FindQuestionByID(ctx context.Context, questionID uint) (models.Question, error) {
 	log := logrus.WithContext(ctx)
 	log.Infof("Finding question by ID: %d", questionID)
 
+	// Deleted questions are still shown in the answers given to them.
 	var question models.Question
-	err := r.db.Preload("Options").First(&question, questionID).Error
+	err := shared.Conn(ctx, r.db).Unscoped().Preload("Options").First(&question, questionID).Error
 	if err != nil {
 		log.WithError(err).Error("Error finding question by ID")
 		return models.Question{}, err
@@ -147,16 +325,26 @@ func (r *TriviaRepository) FindQuestionByID(ctx context.Context, questionID uint
 	return question, nil
 }
 
-func (r *TriviaRepository) AssignUserToTrivia(ctx context.Context, triviaID uint, userID uint) error {
+func (r *TriviaRepository) FindQuestionRevision(ctx context.Context, questionID uint, revision int) (models.QuestionRevision, error) {
 	log := logrus.WithContext(ctx)
-	log.Infof("Assigning user ID %d to trivia ID %d", userID, triviaID)
+	log.Infof("Finding revision %d of question ID %d", revision, questionID)
 
-	triviaUser := models.TriviaUser{
-		TriviaID: triviaID,
-		UserID:   userID,
+	var result models.QuestionRevision
+	err := shared.Conn(ctx, r.db).Where("question_id = ? AND revision = ?", questionID, revision).First(&result).Error
+	if err != nil {
+		log.WithError(err).Error("Error finding question revision")
+		return models.QuestionRevision{}, err
 	}
 
-	err := r.db.Create(&triviaUser).Error
+	log.Info("Question revision found successfully")
+	return result, nil
+}
+
+func (r *TriviaRepository) AssignUserToTrivia(ctx context.Context, triviaID uint, userID uint) error {
+	log := logrus.WithContext(ctx)
+	log.Infof("Assigning user ID %d to trivia ID %d", userID, triviaID)
+
+	err := shared.Conn(ctx, r.db).Model(&models.Trivia{ID: triviaID}).Association("Users").Append(&models.UserModel{ID: userID})
 	if err != nil {
 		log.WithError(err).Error("Error assigning user to trivia")
 		return err
@@ -166,23 +354,78 @@ func (r *TriviaRepository) AssignUserToTrivia(ctx context.Context, triviaID uint
 	return nil
 }
 
-func (r *TriviaRepository) GetTriviaRanking(ctx context.Context, triviaID uint) ([]models.Ranking, error) {
+func (r *TriviaRepository) UnassignUserFromTrivia(ctx context.Context, triviaID uint, userID uint) error {
+	log := logrus.WithContext(ctx)
+	log.Infof("Unassigning user ID %d from trivia ID %d", userID, triviaID)
+
+	err := shared.Conn(ctx, r.db).Model(&models.Trivia{ID: triviaID}).Association("Users").Delete(&models.UserModel{ID: userID})
+	if err != nil {
+		log.WithError(err).Error("Error unassigning user from trivia")
+		return err
+	}
+
+	log.Info("User unassigned from trivia successfully")
+	return nil
+}
+
+func (r *TriviaRepository) IsUserAssigned(ctx context.Context, triviaID uint, userID uint) (bool, error) {
+	log := logrus.WithContext(ctx)
+	log.Infof("Checking assignment of user ID %d to trivia ID %d", userID, triviaID)
+
+	association := shared.Conn(ctx, r.db).Model(&models.Trivia{ID: triviaID}).Where("user_models.id = ?", userID).Association("Users")
+	count := association.Count()
+	if association.Error != nil {
+		log.WithError(association.Error).Error("Error checking user assignment")
+		return false, association.Error
+	}
+
+	return count > 0, nil
+}
+
+func (r *TriviaRepository) GetTriviaRanking(ctx context.Context, triviaID uint, limit, offset int) ([]models.Ranking, int64, error) {
 	log := logrus.WithContext(ctx)
 	log.Infof("Getting ranking for trivia ID %d", triviaID)
 
+	counted := shared.CountedParticipations(shared.Conn(ctx, r.db), nil, nil)
+
+	var total int64
+	err := shared.Conn(ctx, r.db).Table("participations").
+		Joins("JOIN user_models ON user_models.id = participations.user_id AND user_models.deleted_at IS NULL").
+		Where("participations.trivia_id = ?", triviaID).
+		Where("participations.id IN (?)", counted).
+		Distinct("participations.user_id").
+		Count(&total).Error
+	if err != nil {
+		log.WithError(err).Error("Error counting trivia ranking")
+		return nil, 0, err
+	}
+
+	correctAnswers := shared.Conn(ctx, r.db).Table("answers").
+		Select("participation_id, COUNT(*) AS correct_answers").
+		Where("is_correct").
+		Group("participation_id")
+
 	var rankings []models.Ranking
-	err := r.db.Table("participations").
-		Select("user_id, SUM(score) as total_score").
-		Where("trivia_id = ?", triviaID).
-		Group("user_id").
-		Order("total_score DESC").
-		Find(&rankings).Error
+	err = shared.Conn(ctx, r.db).Table("participations").
+		Select("RANK() OVER (ORDER BY SUM(participations.score) DESC) AS rank, "+
+			"participations.user_id, user_models.name, "+
+			"SUM(participations.score) AS total_score, "+
+			"COALESCE(SUM(correct.correct_answers), 0) AS correct_answers").
+		Joins("JOIN user_models ON user_models.id = participations.user_id AND user_models.deleted_at IS NULL").
+		Joins("LEFT JOIN (?) AS correct ON correct.participation_id = participations.id", correctAnswers).
+		Where("participations.trivia_id = ?", triviaID).
+		Where("participations.id IN (?)", counted).
+		Group("participations.user_id, user_models.name").
+		Order("total_score DESC, correct_answers DESC, participations.user_id").
+		Limit(limit).
+		Offset(offset).
+		Scan(&rankings).Error
 
 	if err != nil {
 		log.WithError(err).Error("Error retrieving trivia ranking")
-		return nil, err
+		return nil, 0, err
 	}
 
 	log.Info("Trivia ranking retrieved successfully")
-	return rankings, nil
+	return rankings, total, nil
 }
@@ -9,11 +9,23 @@ type TriviaRepositoryInterface interface {
 	CreateTrivia(ctx context.Context, trivia *models.Trivia) error
 	FindAll(ctx context.Context) ([]models.Trivia, error)
 	FindByID(ctx context.Context, id uint) (models.Trivia, error)
+	FindByIDUnscoped(ctx context.Context, id uint) (models.Trivia, error)
 	UpdateTrivia(ctx context.Context, trivia *models.Trivia, id uint) error
+	UpdateStatus(ctx context.Context, id uint, status string) error
 	DeleteTrivia(ctx context.Context, id uint) error
+	RestoreTrivia(ctx context.Context, id uint) error
+	PurgeTrivia(ctx context.Context, id uint) error
 	FindQuestionByID(ctx context.Context, questionID uint) (models.Question, error)
+	FindQuestionRevision(ctx context.Context, questionID uint, revision int) (models.QuestionRevision, error)
 	SaveParticipation(ctx context.Context, participation *models.Participation) error
+	FindParticipationByIdempotencyKey(ctx context.Context, userID uint, key string) (models.Participation, error)
 	GetUserScore(ctx context.Context, triviaID, userID uint) (models.Participation, error)
+	CountAttempts(ctx context.Context, triviaID, userID uint) (int64, error)
+	FindLastAttempt(ctx context.Context, triviaID, userID uint) (models.Participation, error)
+	FindParticipationsByUser(ctx context.Context, userID uint) ([]models.Participation, error)
+	FindAnsweredQuestionIDs(ctx context.Context, userIDs []uint) ([]uint, error)
 	AssignUserToTrivia(ctx context.Context, TriviaID, UserID uint) error
-	GetTriviaRanking(ctx context.Context, triviaID uint) ([]models.Ranking, error)
+	UnassignUserFromTrivia(ctx context.Context, triviaID, userID uint) error
+	IsUserAssigned(ctx context.Context, triviaID, userID uint) (bool, error)
+	GetTriviaRanking(ctx context.Context, triviaID uint, limit, offset int) ([]models.Ranking, int64, error)
 }
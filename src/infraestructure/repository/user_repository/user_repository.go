@@ -3,10 +3,12 @@ package repository
 import (
 	"context"
 	"talana_prueba_tecnica/src/entity/models"
+	"talana_prueba_tecnica/src/shared"
 
 	log "github.com/sirupsen/logrus"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type UserRepository struct {
@@ -24,7 +26,7 @@ func (r *UserRepository) FindAll(ctx context.Context) ([]models.UserModel, error
 
 	log.Info("finding all users")
 
-	res := r.gorm.WithContext(ctx).Find(&users)
+	res := shared.Conn(ctx, r.gorm).Preload("Roles").Find(&users)
 	if res.Error != nil {
 		log.Error("Error finding all users")
 		return nil, res.Error
@@ -40,7 +42,7 @@ func (r *UserRepository) FindByID(ctx context.Context, id uint) (*models.UserMod
 
 	log.Info("finding user by id")
 
-	res := r.gorm.WithContext(ctx).First(&user, id)
+	res := shared.Conn(ctx, r.gorm).Preload("Roles").First(&user, id)
 	if res.Error != nil {
 		log.Error("Error finding user by id")
 		return nil, res.Error
@@ -50,12 +52,28 @@ func (r *UserRepository) FindByID(ctx context.Context, id uint) (*models.UserMod
 	return &user, nil
 }
 
+func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*models.UserModel, error) {
+	log.WithContext(ctx).Println("finding user by email")
+	var user models.UserModel
+
+	log.Info("finding user by email")
+
+	res := shared.Conn(ctx, r.gorm).Preload("Roles").Where("email = ?", email).First(&user)
+	if res.Error != nil {
+		log.Error("Error finding user by email")
+		return nil, res.Error
+	}
+
+	log.WithError(res.Error).Info("user found")
+	return &user, nil
+}
+
 func (r *UserRepository) Create(ctx context.Context, user *models.UserModel) error {
 	log.WithContext(ctx).Println("creating user in repository")
 
 	log.Info("creating user")
 
-	res := r.gorm.WithContext(ctx).Create(user)
+	res := shared.Conn(ctx, r.gorm).Create(user)
 	if res.Error != nil {
 		log.Error("Error creating user")
 		return res.Error
@@ -70,7 +88,7 @@ func (r *UserRepository) Update(ctx context.Context, user *models.UserModel, id
 
 	log.Info("updating user")
 
-	res := r.gorm.WithContext(ctx).Model(&models.UserModel{}).Where("id = ?", id).Updates(user)
+	res := shared.Conn(ctx, r.gorm).Model(&models.UserModel{}).Where("id = ?", id).Omit(clause.Associations).Updates(user)
 	if res.Error != nil {
 		log.Error("Error updating user")
 		return res.Error
@@ -80,12 +98,14 @@ func (r *UserRepository) Update(ctx context.Context, user *models.UserModel, id
 	return nil
 }
 
+// Delete soft deletes the user, keeping their roles and participations so
+// they can be restored.
 func (r *UserRepository) Delete(ctx context.Context, id uint) error {
 	log.WithContext(ctx).Println("deleting user")
 
 	log.Info("deleting user")
 
-	res := r.gorm.WithContext(ctx).Delete(&models.UserModel{}, id)
+	res := shared.Conn(ctx, r.gorm).Delete(&models.UserModel{}, id)
 	if res.Error != nil {
 		log.Error("Error deleting user")
 		return res.Error
@@ -94,3 +114,92 @@ func (r *UserRepository) Delete(ctx context.Context, id uint) error {
 	log.WithError(res.Error).Info("user deleted")
 	return nil
 }
+
+// Restore undoes Delete, returning gorm.ErrRecordNotFound unless the user
+// is deleted.
+func (r *UserRepository) Restore(ctx context.Context, id uint) error {
+	log.WithContext(ctx).Println("restoring user")
+
+	log.Infof("restoring user %d", id)
+
+	res := shared.Conn(ctx, r.gorm).Unscoped().Model(&models.UserModel{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if res.Error != nil {
+		log.Error("Error restoring user")
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	log.Info("user restored")
+	return nil
+}
+
+// Purge permanently removes a deleted user with their roles, trivia
+// assignments, participations and idempotency records. It returns
+// gorm.ErrRecordNotFound unless the user is deleted.
+func (r *UserRepository) Purge(ctx context.Context, id uint) error {
+	log.WithContext(ctx).Println("purging user")
+
+	log.Infof("purging user %d", id)
+
+	err := shared.Conn(ctx, r.gorm).Transaction(func(tx *gorm.DB) error {
+		var user models.UserModel
+		if err := tx.Unscoped().Where("deleted_at IS NOT NULL").First(&user, id).Error; err != nil {
+			return err
+		}
+
+		// Answers and game sessions cascade with their participation.
+		if err := tx.Where("user_id = ?", id).Delete(&models.Participation{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM trivia_users WHERE user_model_id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", id).Delete(&models.IdempotencyRecord{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Select("Roles").Delete(&user).Error
+	})
+	if err != nil {
+		log.Error("Error purging user")
+		return err
+	}
+
+	log.Info("user purged")
+	return nil
+}
+
+func (r *UserRepository) AddRole(ctx context.Context, userID uint, role string) error {
+	log.WithContext(ctx).Println("adding role to user")
+
+	log.Infof("adding role %s to user %d", role, userID)
+
+	res := shared.Conn(ctx, r.gorm).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.UserRole{UserID: userID, Role: role})
+	if res.Error != nil {
+		log.Error("Error adding role to user")
+		return res.Error
+	}
+
+	log.WithError(res.Error).Info("role added")
+	return nil
+}
+
+func (r *UserRepository) RemoveRole(ctx context.Context, userID uint, role string) error {
+	log.WithContext(ctx).Println("removing role from user")
+
+	log.Infof("removing role %s from user %d", role, userID)
+
+	res := shared.Conn(ctx, r.gorm).Where("user_id = ? AND role = ?", userID, role).Delete(&models.UserRole{})
+	if res.Error != nil {
+		log.Error("Error removing role from user")
+		return res.Error
+	}
+
+	log.WithError(res.Error).Info("role removed")
+	return nil
+}
@@ -8,7 +8,12 @@ import (
 type UserRepositoryInterface interface {
 	FindAll(ctx context.Context) ([]models.UserModel, error)
 	FindByID(ctx context.Context, id uint) (*models.UserModel, error)
+	FindByEmail(ctx context.Context, email string) (*models.UserModel, error)
 	Create(ctx context.Context, user *models.UserModel) error
 	Update(ctx context.Context, user *models.UserModel, id uint) error
 	Delete(ctx context.Context, id uint) error
+	Restore(ctx context.Context, id uint) error
+	Purge(ctx context.Context, id uint) error
+	AddRole(ctx context.Context, userID uint, role string) error
+	RemoveRole(ctx context.Context, userID uint, role string) error
 }
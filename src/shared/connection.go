@@ -3,9 +3,11 @@ package shared
 import (
 	"log"
 	"talana_prueba_tecnica/src/entity/models"
+	"time"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 var Env = GetEnvs()
@@ -24,16 +26,127 @@ func Init() *gorm.DB {
 }
 
 func migration(db *gorm.DB) {
+	if err := dedupeAnswers(db); err != nil {
+		log.Fatal("Failed to remove duplicated answers: ", err)
+	}
 	err := db.AutoMigrate(
 		&models.UserModel{},
+		&models.UserRole{},
 		&models.Trivia{},
+		&models.Category{},
+		&models.Tag{},
 		&models.Question{},
+		&models.QuestionRevision{},
 		&models.Option{},
 		&models.Participation{},
 		&models.Answer{},
+		&models.GameSession{},
+		&models.SessionQuestion{},
+		&models.Season{},
+		&models.IdempotencyRecord{},
+		&models.ReviewEvent{},
 	)
 	if err != nil {
 		log.Fatal("Failed to migrate database: ", err)
 	}
+	if err := backfillQuestionRevisions(db); err != nil {
+		log.Fatal("Failed to backfill question revisions: ", err)
+	}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := backfillAnswerOptionIndexes(tx); err != nil {
+			return err
+		}
+		return backfillParticipationTimes(tx)
+	})
+	if err != nil {
+		log.Fatal("Failed to backfill legacy participations: ", err)
+	}
 	log.Println("Database migrated")
 }
+
+// dedupeAnswers keeps only the first answer of each question in a
+// participation, so answers can be unique per participation and question.
+func dedupeAnswers(db *gorm.DB) error {
+	if !db.Migrator().HasTable(&models.Answer{}) {
+		return nil
+	}
+	res := db.Exec("DELETE FROM answers USING answers AS first " +
+		"WHERE answers.participation_id = first.participation_id " +
+		"AND answers.question_id = first.question_id AND answers.id > first.id")
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected > 0 {
+		log.Printf("Removed %d duplicated answers", res.RowsAffected)
+	}
+	return nil
+}
+
+// backfillQuestionRevisions records the current revision of the questions
+// created before revisions were recorded.
+func backfillQuestionRevisions(db *gorm.DB) error {
+	var questions []models.Question
+	err := db.Unscoped().Preload("Options").
+		Where("NOT EXISTS (SELECT 1 FROM question_revisions WHERE question_revisions.question_id = questions.id AND question_revisions.revision = questions.revision)").
+		Find(&questions).Error
+	if err != nil {
+		return err
+	}
+
+	for i := range questions {
+		revision := models.NewQuestionRevision(&questions[i])
+		if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(revision).Error; err != nil {
+			return err
+		}
+	}
+	if len(questions) > 0 {
+		log.Printf("Recorded revisions of %d questions", len(questions))
+	}
+	return nil
+}
+
+// legacyParticipations are the participations saved before attempts were
+// timed, recognized by their missing finished_at until
+// backfillParticipationTimes sets it.
+const legacyParticipations = "SELECT id FROM participations WHERE finished_at IS NULL " +
+	"AND NOT EXISTS (SELECT 1 FROM game_sessions WHERE game_sessions.participation_id = participations.id)"
+
+// backfillAnswerOptionIndexes rewrites the options selected in legacy
+// participations, which players picked by option ID, as the index of the
+// option in its question, the way every answer now references them. It must
+// run before backfillParticipationTimes.
+func backfillAnswerOptionIndexes(db *gorm.DB) error {
+	res := db.Exec("UPDATE answers SET selected_option = ranked.position " +
+		"FROM (SELECT id, question_id, ROW_NUMBER() OVER (PARTITION BY question_id ORDER BY id) - 1 AS position FROM options) AS ranked " +
+		"WHERE ranked.id = answers.selected_option AND ranked.question_id = answers.question_id " +
+		"AND answers.participation_id IN (" + legacyParticipations + ")")
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected > 0 {
+		log.Printf("Rewrote %d legacy answers as option indexes", res.RowsAffected)
+	}
+	return nil
+}
+
+// backfillParticipationTimes marks the participations saved before attempts
+// were timed as finished, so rankings, scores and histories keep counting
+// them. Their real time is unknown, so they take the time of the migration,
+// which precedes every timed attempt. Participations of a game session are
+// left alone: theirs is set when the session ends.
+func backfillParticipationTimes(db *gorm.DB) error {
+	now := time.Now()
+	res := db.Model(&models.Participation{}).
+		Where("id IN (" + legacyParticipations + ")").
+		Updates(map[string]interface{}{
+			"started_at":  gorm.Expr("COALESCE(started_at, ?)", now),
+			"finished_at": now,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected > 0 {
+		log.Printf("Marked %d legacy participations as finished", res.RowsAffected)
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package shared
+
+import (
+	"context"
+	"talana_prueba_tecnica/src/entity/models"
+)
+
+// CurrentUserKey is the fiber local holding the authenticated user. Fiber
+// locals are exposed through the request context, so usecases receiving
+// ctx.Context() can read it with CurrentUser.
+const CurrentUserKey = "current_user"
+
+// CurrentUser returns the user authenticated for the request, if any.
+func CurrentUser(ctx context.Context) (*models.UserModel, bool) {
+	user, ok := ctx.Value(CurrentUserKey).(*models.UserModel)
+	return user, ok && user != nil
+}
@@ -1,15 +1,30 @@
 package shared
 
 import (
+	"errors"
+	"io/fs"
 	"log"
 	"os"
+	"path/filepath"
 
 	"github.com/joho/godotenv"
 )
 
+// minJWTSecretLength is the shortest JWT_SECRET accepted, the size of an
+// HS256 key.
+const minJWTSecretLength = 32
+
+// publishedJWTSecrets are secrets that were shipped with the repository, so
+// anyone could sign tokens with them.
+var publishedJWTSecrets = []string{
+	"cambiar_este_secreto_por_uno_aleatorio_de_32_bytes_o_mas",
+}
+
+// GetEnvs returns the configuration of the application, read from the
+// environment and the .env file when there is one.
 func GetEnvs() map[string]string {
-	err := godotenv.Load()
-	if err != nil {
+	err := godotenv.Load(envFile())
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
 		log.Fatal("Error loading .env file")
 	}
 
@@ -21,6 +36,41 @@ func GetEnvs() map[string]string {
 		"DB_PORT":     os.Getenv("DB_PORT"),
 		"DB_SSLMODE":  os.Getenv("DB_SSLMODE"),
 		"PORT":        os.Getenv("PORT"),
+		"JWT_SECRET":  os.Getenv("JWT_SECRET"),
+	}
+}
+
+// CheckEnvs stops the server unless JWT_SECRET is a key of its own: long
+// enough for HS256 and not one published with the repository.
+func CheckEnvs(envs map[string]string) {
+	secret := envs["JWT_SECRET"]
+	if len(secret) < minJWTSecretLength {
+		log.Fatalf("JWT_SECRET must be set to at least %d bytes", minJWTSecretLength)
+	}
+	for _, published := range publishedJWTSecrets {
+		if secret == published {
+			log.Fatal("JWT_SECRET is the example value; generate one of your own")
+		}
 	}
+}
 
+// envFile returns the .env of the working directory or of its closest
+// parent, so commands run from a package directory, like go test, use the
+// one at the root of the repository.
+func envFile() string {
+	dir, err := os.Getwd()
+	if err != nil {
+		return ".env"
+	}
+	for {
+		path := filepath.Join(dir, ".env")
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ".env"
+		}
+		dir = parent
+	}
 }
@@ -0,0 +1,21 @@
+package shared
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// NormalizePage clamps pagination parameters to sane values and returns the
+// page, page size and row offset to use.
+func NormalizePage(page, pageSize int) (int, int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+	return page, pageSize, (page - 1) * pageSize
+}
@@ -0,0 +1,26 @@
+package shared
+
+import (
+	"errors"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const MinPasswordLength = 8
+
+var ErrPasswordTooShort = errors.New("password must be at least 8 characters long")
+
+func HashPassword(password string) (string, error) {
+	if len(password) < MinPasswordLength {
+		return "", ErrPasswordTooShort
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+func CheckPassword(hash, password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
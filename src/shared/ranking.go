@@ -0,0 +1,32 @@
+package shared
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CountedParticipations selects the IDs of the finished participations that
+// count in rankings: one per user and trivia, picked by the ranking policy of
+// the trivia (best score, latest or first attempt) among the participations
+// finished in [from, to). Nil bounds are open.
+func CountedParticipations(db *gorm.DB, from, to *time.Time) *gorm.DB {
+	ranked := db.Table("participations").
+		Select("participations.id, ROW_NUMBER() OVER (" +
+			"PARTITION BY participations.user_id, participations.trivia_id ORDER BY " +
+			"CASE WHEN trivias.ranking_policy = 'best' THEN participations.score END DESC, " +
+			"CASE WHEN trivias.ranking_policy = 'first' THEN participations.finished_at END ASC, " +
+			"participations.finished_at DESC) AS attempt_rank").
+		Joins("JOIN trivias ON trivias.id = participations.trivia_id").
+		Where("participations.finished_at IS NOT NULL")
+	if from != nil {
+		ranked = ranked.Where("participations.finished_at >= ?", *from)
+	}
+	if to != nil {
+		ranked = ranked.Where("participations.finished_at < ?", *to)
+	}
+
+	return db.Table("(?) AS ranked_participations", ranked).
+		Select("ranked_participations.id").
+		Where("ranked_participations.attempt_rank = 1")
+}
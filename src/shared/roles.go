@@ -0,0 +1,10 @@
+package shared
+
+import "talana_prueba_tecnica/src/entity/models"
+
+// InitialRoles returns the roles granted to a new user: everyone plays. Other
+// roles are granted by an admin, and the first admin with the -grant-admin
+// flag of the server.
+func InitialRoles() []models.UserRole {
+	return []models.UserRole{{Role: models.RolePlayer}}
+}
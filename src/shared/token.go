@@ -0,0 +1,60 @@
+package shared
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	AccessTokenType  = "access"
+	RefreshTokenType = "refresh"
+
+	AccessTokenTTL  = 15 * time.Minute
+	RefreshTokenTTL = 7 * 24 * time.Hour
+)
+
+var ErrInvalidToken = errors.New("invalid or expired token")
+
+type TokenClaims struct {
+	Type string `json:"typ"`
+	jwt.RegisteredClaims
+}
+
+// GenerateToken signs a token of the given type for userID with JWT_SECRET.
+func GenerateToken(userID uint, tokenType string, ttl time.Duration) (string, error) {
+	now := time.Now()
+	claims := TokenClaims{
+		Type: tokenType,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   strconv.FormatUint(uint64(userID), 10),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(jwtSecret())
+}
+
+// ParseToken validates a token of the given type and returns the user ID it
+// was issued for.
+func ParseToken(token, tokenType string) (uint, error) {
+	var claims TokenClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(*jwt.Token) (interface{}, error) {
+		return jwtSecret(), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil || claims.Type != tokenType {
+		return 0, ErrInvalidToken
+	}
+
+	userID, err := strconv.ParseUint(claims.Subject, 10, 64)
+	if err != nil {
+		return 0, ErrInvalidToken
+	}
+	return uint(userID), nil
+}
+
+func jwtSecret() []byte {
+	return []byte(Env["JWT_SECRET"])
+}
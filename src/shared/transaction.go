@@ -0,0 +1,45 @@
+package shared
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+// UnitOfWork runs a group of repository calls atomically.
+type UnitOfWork interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type GormUnitOfWork struct {
+	db *gorm.DB
+}
+
+func NewUnitOfWork(db *gorm.DB) *GormUnitOfWork {
+	return &GormUnitOfWork{db: db}
+}
+
+// Do runs fn inside a transaction carried by the context it receives, so
+// every repository resolving its connection with Conn joins it. The
+// transaction is rolled back when fn returns an error. Nested calls reuse
+// the outer transaction.
+func (u *GormUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+
+	return u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// Conn returns the transaction opened by a UnitOfWork for ctx, or db when
+// the call is not part of one.
+func Conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx
+	}
+	return db.WithContext(ctx)
+}